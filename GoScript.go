@@ -27,11 +27,71 @@ import (
 
 func main() {
 	var srcFile *string = flag.String("src", "", "Source js file to compile into go")
+	var format *string = flag.String("format", "iife", "Kind of module to write: iife, esm, cjs")
+	var target *string = flag.String("target", "browser", "Environment to run the code: browser, node (whose format is cjs by default)")
 	flag.Parse()
 	if len(*srcFile) == 0 {
 		fmt.Println("Must specify srcFile")
 		flag.PrintDefaults()
 		os.Exit(1)
 	}
-	gojs.Compile(*srcFile)
+
+	var opts gojs.Options
+
+	switch *format {
+	case "iife":
+		opts.Format = gojs.IIFE
+	case "esm":
+		opts.Format = gojs.ESModule
+	case "cjs":
+		opts.Format = gojs.CommonJS
+	default:
+		fmt.Println("Unknown format:", *format)
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+
+	switch *target {
+	case "browser":
+		opts.Target = gojs.Browser
+	case "node":
+		opts.Target = gojs.Node
+
+		isFormat := false
+		flag.Visit(func(f *flag.Flag) { isFormat = isFormat || f.Name == "format" })
+		if !isFormat {
+			opts.Format = gojs.CommonJS
+		}
+	default:
+		fmt.Println("Unknown target:", *target)
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+
+	diag, err := gojs.Compile(*srcFile, opts)
+
+	printDiag(" == Errors", diag.Errors())
+	printDiag(" == Warnings", diag.Warnings())
+
+	if err != nil {
+		if diag == nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		os.Exit(1)
+	}
+}
+
+// Prints the diagnostics under the given title.
+func printDiag(title string, diag gojs.Diagnostics) {
+	if len(diag) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%s\n\n", title)
+
+	for _, d := range diag {
+		fmt.Fprintln(os.Stderr, d)
+	}
+	if len(diag) == gojs.MaxMessage {
+		fmt.Fprintln(os.Stderr, "\n Too many messages")
+	}
 }
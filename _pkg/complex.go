@@ -0,0 +1,266 @@
+// Copyright 2012  The "GoScript" Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Handle the complex numbers.
+
+package g
+
+// The complex numbers are stored in their real and imaginary parts. Both
+// complex64 and complex128 use the type Complex; the values of complex64 are
+// rounded by the method "fround" after of each operation.
+// The values are immutable; every operation returns a new one.
+
+// Complex represents a complex64 or a complex128.
+type Complex struct {
+	re float64 // real part
+	im float64 // imaginary part
+}
+
+func (x Complex) add(y Complex) Complex { return Complex{x.re + y.re, x.im + y.im} }
+func (x Complex) sub(y Complex) Complex { return Complex{x.re - y.re, x.im - y.im} }
+func (x Complex) neg() Complex          { return Complex{-x.re, -x.im} }
+
+// Returns x * y.
+func (x Complex) mul(y Complex) Complex {
+	return Complex{x.re*y.re - x.im*y.im, x.re*y.im + x.im*y.re}
+}
+
+// Returns x / y, like the runtime of Go.
+// The division by zero does not panic; it returns infinities or NaNs.
+func (x Complex) div(y Complex) Complex {
+	re, im := 0, 0
+
+	// Robert L. Smith: Algorithm 116: Complex division.
+	if Math.abs(y.re) >= Math.abs(y.im) {
+		ratio := y.im / y.re
+		denom := y.re + ratio*y.im
+		re = (x.re + x.im*ratio) / denom
+		im = (x.im - x.re*ratio) / denom
+	} else {
+		ratio := y.re / y.im
+		denom := y.im + ratio*y.re
+		re = (x.re*ratio + x.im) / denom
+		im = (x.im*ratio - x.re) / denom
+	}
+
+	// The division by zero of a number which is not NaN.
+	if isNaN(re) && isNaN(im) && y.re == 0 && y.im == 0 && (!isNaN(x.re) || !isNaN(x.im)) {
+		inf := Infinity
+		if 1/y.re < 0 {
+			inf = -Infinity
+		}
+		re = inf * x.re
+		im = inf * x.im
+	}
+	return Complex{re, im}
+}
+
+// Reports whether x == y.
+// The parts are compared by "<=" and ">=" since the package is transformed
+// without types, and "==" would compare their representation.
+func (x Complex) eq(y Complex) bool {
+	return x.re <= y.re && x.re >= y.re && x.im <= y.im && x.im >= y.im
+}
+
+// Returns x rounded to complex64.
+func (x Complex) fround() Complex { return Complex{Math.fround(x.re), Math.fround(x.im)} }
+
+// Returns x like Go formats it with the verb "%v".
+// The size of the parts is 32 bits for a complex64; else, 64 bits.
+func (x Complex) toString(size int) string {
+	im := FormatFloat(x.im, size)
+	if sign := im.charAt(0); sign != "-" && sign != "+" {
+		im = "+" + im
+	}
+	return "(" + FormatFloat(x.re, size) + im + "i)"
+}
+
+// Returns the number f like Go formats it with the verb "%v", with the
+// shortest digits which represent it at its size in bits. It is used the
+// exponent when it is less than -4 or greater than or equal to 6.
+func FormatFloat(f float64, size int) string {
+	switch {
+	case isNaN(f):
+		return "NaN"
+	case f > Number.MAX_VALUE:
+		return "+Inf"
+	case f < -Number.MAX_VALUE:
+		return "-Inf"
+	case f == 0 && 1/f < 0:
+		return "-0"
+	}
+
+	if size == 32 {
+		for p := 1; p < 9; p++ {
+			if d := Number(f.toPrecision(p)); Math.fround(d) <= f && Math.fround(d) >= f {
+				f = d
+				break
+			}
+		}
+	}
+
+	s := f.toExponential()
+	exp := Number(s.slice(s.indexOf("e") + 1))
+	if exp >= -4 && exp < 6 {
+		return String(f)
+	}
+
+	// At least two digits in the exponent.
+	mant := s.slice(0, s.indexOf("e")+2)
+	if Math.abs(exp) < 10 {
+		mant += "0"
+	}
+	return mant + String(Math.abs(exp))
+}
+
+// == Package "math/cmplx"
+//
+
+// Returns the absolute value of x.
+func CmplxAbs(x Complex) float64 { return Math.hypot(x.re, x.im) }
+
+// Returns the complex conjugate of x.
+func CmplxConj(x Complex) Complex { return Complex{x.re, -x.im} }
+
+// Returns the phase of x, in the range [-Pi, Pi].
+func CmplxPhase(x Complex) float64 { return Math.atan2(x.im, x.re) }
+
+// Returns the absolute value and the phase of x.
+func CmplxPolar(x Complex) (float64, float64) { return CmplxAbs(x), CmplxPhase(x) }
+
+// Returns the complex number with polar coordinates r, θ.
+func CmplxRect(r, θ float64) Complex { return Complex{r * Math.cos(θ), r * Math.sin(θ)} }
+
+// Returns a complex infinity.
+func CmplxInf() Complex { return Complex{Infinity, Infinity} }
+
+// Returns a complex "not-a-number".
+func CmplxNaN() Complex { return Complex{NaN, NaN} }
+
+// Reports whether either real(x) or imag(x) is an infinity.
+func CmplxIsInf(x Complex) bool {
+	return Math.abs(x.re) > Number.MAX_VALUE || Math.abs(x.im) > Number.MAX_VALUE
+}
+
+// Reports whether either real(x) or imag(x) is NaN and neither is an infinity.
+func CmplxIsNaN(x Complex) bool {
+	return !CmplxIsInf(x) && (isNaN(x.re) || isNaN(x.im))
+}
+
+// Returns e**x.
+func CmplxExp(x Complex) Complex {
+	r := Math.exp(x.re)
+	return Complex{r * Math.cos(x.im), r * Math.sin(x.im)}
+}
+
+// Returns the natural logarithm of x.
+func CmplxLog(x Complex) Complex {
+	return Complex{Math.log(CmplxAbs(x)), CmplxPhase(x)}
+}
+
+// Returns x**y.
+func CmplxPow(x, y Complex) Complex {
+	if x.re == 0 && x.im == 0 {
+		switch {
+		case CmplxIsNaN(y):
+			return CmplxNaN()
+		case y.re == 0:
+			return Complex{1, 0}
+		case y.re < 0:
+			if y.im == 0 {
+				return Complex{Infinity, 0}
+			}
+			return CmplxInf()
+		}
+		return Complex{0, 0}
+	}
+
+	modulus := CmplxAbs(x)
+	r := Math.pow(modulus, y.re)
+	arg := CmplxPhase(x)
+	theta := y.re * arg
+
+	if y.im != 0 {
+		r *= Math.exp(-y.im * arg)
+		theta += y.im * Math.log(modulus)
+	}
+	return Complex{r * Math.cos(theta), r * Math.sin(theta)}
+}
+
+// Returns the square root of x, whose real part is not negative.
+func CmplxSqrt(x Complex) Complex {
+	if x.im == 0 {
+		switch {
+		case x.re == 0:
+			return Complex{0, x.im}
+		case x.re < 0:
+			if 1/x.im < 0 {
+				return Complex{0, -Math.sqrt(-x.re)}
+			}
+			return Complex{0, Math.sqrt(-x.re)}
+		}
+		return Complex{Math.sqrt(x.re), x.im}
+	}
+
+	if Math.abs(x.im) > Number.MAX_VALUE {
+		return Complex{Infinity, x.im}
+	}
+	if x.re == 0 {
+		if x.im < 0 {
+			r := Math.sqrt(-0.5 * x.im)
+			return Complex{r, -r}
+		}
+		r := Math.sqrt(0.5 * x.im)
+		return Complex{r, r}
+	}
+
+	// Rescale to avoid internal overflow or underflow.
+	a, b, scale := x.re, x.im, 0
+	if Math.abs(a) > 4 || Math.abs(b) > 4 {
+		a *= 0.25
+		b *= 0.25
+		scale = 2
+	} else {
+		a *= 1.8014398509481984e16 // 2**54
+		b *= 1.8014398509481984e16
+		scale = 7.450580596923828125e-9 // 2**-27
+	}
+
+	r := Math.hypot(a, b)
+	t := 0
+	if a > 0 {
+		t = Math.sqrt(0.5*r + 0.5*a)
+		r = scale * Math.abs((0.5*b)/t)
+		t *= scale
+	} else {
+		r = Math.sqrt(0.5*r - 0.5*a)
+		t = scale * Math.abs((0.5*b)/r)
+		r *= scale
+	}
+
+	if b < 0 {
+		return Complex{t, -r}
+	}
+	return Complex{t, r}
+}
+
+// Returns the sine of x.
+func CmplxSin(x Complex) Complex {
+	return Complex{Math.sin(x.re) * Math.cosh(x.im), Math.cos(x.re) * Math.sinh(x.im)}
+}
+
+// Returns the cosine of x.
+func CmplxCos(x Complex) Complex {
+	return Complex{Math.cos(x.re) * Math.cosh(x.im), -Math.sin(x.re) * Math.sinh(x.im)}
+}
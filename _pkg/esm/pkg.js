@@ -0,0 +1,1468 @@
+/* Generated by GoScript <github.com/kless/GoScript> */
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+export function Export(pkg, exported) {
+	var v; for (var _ in exported) { v = exported[_];
+		pkg.v = v;
+	}
+}
+
+
+
+
+
+
+export function Func(f) {
+	if (f === undefined) {
+		throw new Error("runtime error: invalid memory address or nil pointer dereference");
+	}
+	return f;
+}
+
+
+export function Bind(x, name) {
+	return x[name].bind(x);
+}
+
+
+
+export function MethodExpr(name) {
+	var method = function(x) {
+		return x[name].apply(x, Array.prototype.slice.call(arguments, 1));
+	};
+	return method;
+}
+
+
+
+export function CallFunc(f, x, args) {
+	return f.apply(undefined, Array.of(x).concat(Array.prototype.slice.call(args)));
+}
+
+
+
+export function Deref(f) {
+	var method = function(x) {
+		return CallFunc(f, x.p, Array.prototype.slice.call(arguments, 1));
+	};
+	return method;
+}
+
+
+
+
+
+
+export function Quo(x, y) {
+	if (y === 0) {
+		throw new Error("runtime error: integer divide by zero");
+	}
+	return Math.trunc(x / y);
+}
+
+
+
+export function Rem(x, y) {
+	if (y === 0) {
+		throw new Error("runtime error: integer divide by zero");
+	}
+	return x % y;
+}
+
+
+
+export function Shl(x, y) {
+	if (y < 0) {
+		throw new Error("runtime error: negative shift amount");
+	}
+	if (y >= 64) {
+		return 0;
+	}
+	return x * Math.pow(2, y);
+}
+
+
+export function Shr(x, y) {
+	if (y < 0) {
+		throw new Error("runtime error: negative shift amount");
+	}
+	if (y > 64) {
+		y = 64;
+	}
+	return Math.floor(x / Math.pow(2, y));
+}
+
+
+
+
+function join32(hi, lo) {
+	if (lo < 0) {
+		lo += 4294967296;
+	}
+	return hi * 4294967296 + lo;
+}
+
+
+export function And(x, y) {
+	var xHi = Math.floor(x / 4294967296), yHi = Math.floor(y / 4294967296);
+	return join32(xHi & yHi, (x - xHi * 4294967296) & (y - yHi * 4294967296));
+}
+
+
+export function Or(x, y) {
+	var xHi = Math.floor(x / 4294967296), yHi = Math.floor(y / 4294967296);
+	return join32(xHi | yHi, (x - xHi * 4294967296) | (y - yHi * 4294967296));
+}
+
+
+export function Xor(x, y) {
+	var xHi = Math.floor(x / 4294967296), yHi = Math.floor(y / 4294967296);
+	return join32(xHi ^ yHi, (x - xHi * 4294967296) ^ (y - yHi * 4294967296));
+}
+
+
+export function AndNot(x, y) {
+	var xHi = Math.floor(x / 4294967296), yHi = Math.floor(y / 4294967296);
+	return join32(xHi & ~yHi, (x - xHi * 4294967296) & ~(y - yHi * 4294967296));
+}
+
+
+
+
+
+export function S(f, len, cap) {
+	this.f=f;
+	this.len=len;
+	this.cap=cap;
+}
+
+
+export function NewSlice(i, low, high) {
+	var s = new S([], 0, 0);
+	s.set(i, low, high);
+	return s;
+}
+
+
+export function MakeSlice(zero, len, cap) {
+	var s = new S([], 0, 0);
+
+	for (var i = 0; i < len; i++) {
+		s.f[i] = zero;
+	}
+
+	if (cap !== undefined) {
+		s.cap = cap;
+	} else {
+		s.cap = len;
+	}
+	s.len = len;
+
+	return s;
+}
+
+
+
+export function MakeArray(n, zero) {
+	var a = Array(n);
+	for (var i = 0; i < n; i++) {
+		a[i] = zero();
+	}
+	return a;
+}
+
+
+S.prototype.set = function(i, low, high) {
+	if (i.f !== undefined) {
+		this.f = i.f.slice(low, high);
+		this.cap = i.cap - low;
+	} else {
+		this.f = i.slice(low, high);
+		this.cap = i.length - low;
+	}
+
+	this.len = this.f.length;
+}
+
+
+S.prototype.append = function(elt) {
+	if (JSON.stringify(this.len) === JSON.stringify(this.cap)) {
+		this.cap = this.len * 2;
+	}
+	this.len++;
+}
+
+
+S.prototype.toString = function() {
+	return this.f.join("");
+}
+
+
+S.prototype.isNil = function() {
+	if (this.cap !== 0) {
+		return false;
+	}
+	return true;
+}
+
+
+
+
+
+
+export function ArrayEq(x, y, eq) {
+	for (var i = 0; i < x.length; i++) {
+		if (!eq(x[i], y[i])) {
+			return false;
+		}
+	}
+	return true;
+}
+
+
+
+
+
+export function Equal(x, y) {
+	if (x === undefined || y === undefined) {
+		return Object.is(x, y);
+	}
+	if (!Object.is(x.t, y.t)) {
+		return false;
+	}
+	if (Object.is(x.t.eq, Uncomparable)) {
+		throw new Error("runtime error: comparing uncomparable type " + x.t.str);
+	}
+	if (x.t.eq !== undefined) {
+		return x.t.eq(x.v, y.v);
+	}
+
+	if (Number.isNaN(x.v)) {
+		return false;
+	}
+	return Object.is(x.v, y.v) || x.v === 0 && y.v === 0;
+}
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+export function M(f, zero, hash) {
+	this.f=f;
+	this.zero=zero;
+	this.hash=hash;
+}
+
+
+
+
+export function NewMap(zero, kv, hash) {
+	var m = new M(Reflect.construct(Map, Array()), zero, hash);
+	if (kv !== undefined) {
+		for (var i = 0; i < kv.length; i++) {
+			m.entry(kv[i][0])[1] = kv[i][1];
+		}
+	}
+	return m;
+}
+
+
+
+export function NilMap(zero) {
+	return NewMap(zero, undefined, undefined);
+}
+
+
+M.prototype.key = function(k) {
+	if (this.hash !== undefined) {
+		return this.hash(k);
+	}
+	return k;
+}
+
+
+
+M.prototype.get = function(k) {
+	var h = this.key(k);
+	if (Number.isNaN(h)) {
+		return [this.zero, false];
+	}
+
+	var e = this.f.get(h);
+	if (e === undefined) {
+		return [this.zero, false];
+	}
+	return [e[1], true];
+}
+
+
+
+M.prototype.entry = function(k) {
+	var h = this.key(k);
+	if (Number.isNaN(h)) {
+		h = Symbol();
+	}
+
+	var e = this.f.get(h);
+	if (e === undefined) {
+		e = Array(k, this.zero);
+		this.f.set(h, e);
+	}
+	return e;
+}
+
+
+M.prototype.delete = function(k) {
+	this.f.delete(this.key(k));
+}
+
+
+M.prototype.len = function() {
+	return this.f.size;
+}
+
+
+
+
+
+
+export function Hash(parts) {
+	var nan = false;
+	var check = function(k, v) {
+		if (Number.isNaN(v)) {
+			nan = true;
+		}
+		return v;
+	};
+
+	var h = JSON.stringify(parts, check);
+
+	if (nan) {
+		return NaN;
+	}
+	return h;
+}
+
+
+
+export function Id(x) {
+	if (x === undefined) {
+		return 0;
+	}
+	if (!ids.has(x)) {
+		lastId++;
+		ids.set(x, lastId);
+	}
+	return ids.get(x);
+}
+
+
+var ids = Reflect.construct(WeakMap, Array());
+var lastId = 0;
+
+
+
+
+
+export function Key(x) {
+	if (x === undefined || !Object.is(Object(x), x)) {
+		return x;
+	}
+	if (I.prototype.isPrototypeOf(x)) {
+		if (Object.is(x.t.eq, Uncomparable)) {
+			throw new Error("runtime error: hash of unhashable type " + x.t.str);
+		}
+		return new dynamicKey(Id(x.t), Key(x.v));
+	}
+	if (Array.isArray(x)) {
+		var parts = Array();
+		for (var i = 0; i < x.length; i++) {
+			parts.push(Key(x[i]));
+		}
+		return parts;
+	}
+
+
+
+	if (x.eq !== undefined) {
+		var fields = Array();
+		var names = Object.keys(x);
+		for (var i = 0; i < names.length; i++) {
+			fields.push(Key(x[names[i]]));
+		}
+		return new dynamicKey(Id(x.constructor), fields);
+	}
+	return new dynamicKey(Id(x), undefined);
+}
+
+
+
+function dynamicKey(id, fields) {
+	this.id=id;
+	this.fields=fields;
+}
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+function rtype(str, eq, methods, iface, proto) {
+	this.str=str;
+	this.eq=eq;
+	this.methods=methods;
+	this.iface=iface;
+	this.proto=proto;
+}
+
+var typesByName = Reflect.construct(Map, Array());
+
+
+
+export function Uncomparable(x, y) {
+	throw new Error("unreachable");
+}
+
+
+
+
+export function Type(str, eq, methods, funcs) {
+	var t = typesByName.get(str);
+	if (t !== undefined) {
+		return t;
+	}
+	if (methods === undefined) {
+		methods = Array();
+	}
+
+	t = new rtype(str, eq, methods, false, Object.create(I.prototype));
+	for (var i = 0; i < methods.length; i++) {
+		var name = methods[i].slice(0, methods[i].indexOf("("));
+		if (funcs !== undefined) {
+			t.proto[name] = forwardFunc(funcs[name]);
+		} else {
+			t.proto[name] = forward(name);
+		}
+	}
+
+	typesByName.set(str, t);
+	return t;
+}
+
+
+
+export function Iface(str, methods) {
+	var t = typesByName.get(str);
+	if (t === undefined) {
+		t = new rtype(str, undefined, methods, true, undefined);
+		typesByName.set(str, t);
+	}
+	return t;
+}
+
+
+function forward(name) {
+	var method = function() {
+		return this.v[name].apply(this.v, arguments);
+	};
+	return method;
+}
+
+
+
+function forwardFunc(f) {
+	var method = function() {
+		return CallFunc(f, this.v, arguments);
+	};
+	return method;
+}
+
+
+export function I(v, t) {
+	this.v=v;
+	this.t=t;
+}
+
+
+export function Box(v, t) {
+	var x = Object.create(t.proto);
+	x.v = v;
+	x.t = t;
+	return x;
+}
+
+
+I.prototype.toString = function() { return String(this.v); }
+I.prototype.valueOf = function() { return this.v; }
+
+
+
+
+
+
+export function Is(x, t) {
+	if (x === undefined) {
+		return false;
+	}
+	if (!t.iface) {
+		return Object.is(x.t, t);
+	}
+
+	for (var i = 0; i < t.methods.length; i++) {
+		if (!x.t.methods.includes(t.methods[i])) {
+			return false;
+		}
+	}
+	return true;
+}
+
+
+
+
+export function Assert(x, t) {
+	if (!Is(x, t)) {
+		var dynamic = "nil";
+		if (x !== undefined) {
+			dynamic = x.t.str;
+		}
+		throw new Error("interface conversion: interface is " + dynamic + ", not " + t.str);
+	}
+
+	if (t.iface) {
+		return x;
+	}
+	return x.v;
+}
+
+
+
+export function AssertOk(x, t, zero) {
+	if (!Is(x, t)) {
+		return [zero, false];
+	}
+
+	if (t.iface) {
+		return [x, true];
+	}
+	return [x.v, true];
+}
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+const 
+two16 = 65536,
+two31 = 2147483648,
+two32 = 4294967296;
+
+
+
+export function Int64(hi, lo) {
+	this.hi=hi;
+	this.lo=lo;
+}
+
+
+export function Uint64(hi, lo) {
+	this.hi=hi;
+	this.lo=lo;
+}
+
+
+export function ToInt64(n) {
+	return fromNumber(new Int64(0, 0), n);
+}
+
+
+export function ToUint64(n) {
+	return fromNumber(new Uint64(0, 0), n);
+}
+
+
+
+
+Int64.prototype.add = function(y) { return add64(new Int64(0, 0), this, y); }
+Int64.prototype.sub = function(y) { return sub64(new Int64(0, 0), this, y); }
+Int64.prototype.mul = function(y) { return mul64(new Int64(0, 0), this, y); }
+Int64.prototype.and = function(y) { return and64(new Int64(0, 0), this, y); }
+Int64.prototype.or = function(y) { return or64(new Int64(0, 0), this, y); }
+Int64.prototype.xor = function(y) { return xor64(new Int64(0, 0), this, y); }
+Int64.prototype.andNot = function(y) { return andNot64(new Int64(0, 0), this, y); }
+Int64.prototype.shl = function(n) { return shl64(new Int64(0, 0), this, n); }
+Int64.prototype.not = function() { return not64(new Int64(0, 0), this); }
+Int64.prototype.neg = function() { return sub64(new Int64(0, 0), new Int64(0, 0), this); }
+
+
+Int64.prototype.shr = function(n) {
+	if (this.hi < two31) {
+		return shr64(new Int64(0, 0), this, n);
+	}
+	return shr64(new Int64(0, 0), this.not(), n).not();
+}
+
+
+Int64.prototype.div = function(y) {
+	var q = new Int64(0, 0);
+	divmod64(q, new Int64(0, 0), this.abs(), y.abs());
+
+	var neg = this.hi >= two31;
+	if (y.hi >= two31) {
+		neg = !neg;
+	}
+	if (neg) {
+		return q.neg();
+	}
+	return q;
+}
+
+
+Int64.prototype.rem = function(y) {
+	var r = new Int64(0, 0);
+	divmod64(new Int64(0, 0), r, this.abs(), y.abs());
+
+	if (this.hi >= two31) {
+		return r.neg();
+	}
+	return r;
+}
+
+
+
+Int64.prototype.abs = function() {
+	if (this.hi >= two31) {
+		return this.neg();
+	}
+	return this;
+}
+
+
+Int64.prototype.high = function() {
+	if (this.hi >= two31) {
+		return this.hi - two32;
+	}
+	return this.hi;
+}
+
+
+Int64.prototype.cmp = function(y) { return cmp64(this.high(), this.lo, y.high(), y.lo); }
+
+
+Int64.prototype.eq = function(y) { return this.cmp(y) === 0; }
+
+
+Int64.prototype.toNumber = function() { return this.high() * two32 + this.lo; }
+
+Int64.prototype.toUint64 = function() { return new Uint64(this.hi, this.lo); }
+
+
+Int64.prototype.toString = function() {
+	if (this.hi >= two31) {
+		return "-" + utoa(this.neg());
+	}
+	return utoa(this);
+}
+
+
+
+
+Uint64.prototype.add = function(y) { return add64(new Uint64(0, 0), this, y); }
+Uint64.prototype.sub = function(y) { return sub64(new Uint64(0, 0), this, y); }
+Uint64.prototype.mul = function(y) { return mul64(new Uint64(0, 0), this, y); }
+Uint64.prototype.and = function(y) { return and64(new Uint64(0, 0), this, y); }
+Uint64.prototype.or = function(y) { return or64(new Uint64(0, 0), this, y); }
+Uint64.prototype.xor = function(y) { return xor64(new Uint64(0, 0), this, y); }
+Uint64.prototype.andNot = function(y) { return andNot64(new Uint64(0, 0), this, y); }
+Uint64.prototype.shl = function(n) { return shl64(new Uint64(0, 0), this, n); }
+Uint64.prototype.shr = function(n) { return shr64(new Uint64(0, 0), this, n); }
+Uint64.prototype.not = function() { return not64(new Uint64(0, 0), this); }
+Uint64.prototype.neg = function() { return sub64(new Uint64(0, 0), new Uint64(0, 0), this); }
+
+
+Uint64.prototype.div = function(y) {
+	var q = new Uint64(0, 0);
+	divmod64(q, new Uint64(0, 0), this, y);
+	return q;
+}
+
+
+Uint64.prototype.rem = function(y) {
+	var r = new Uint64(0, 0);
+	divmod64(new Uint64(0, 0), r, this, y);
+	return r;
+}
+
+
+Uint64.prototype.cmp = function(y) { return cmp64(this.hi, this.lo, y.hi, y.lo); }
+
+
+Uint64.prototype.eq = function(y) { return this.cmp(y) === 0; }
+
+
+Uint64.prototype.toNumber = function() { return this.hi * two32 + this.lo; }
+
+Uint64.prototype.toInt64 = function() { return new Int64(this.hi, this.lo); }
+
+
+Uint64.prototype.toString = function() { return utoa(this); }
+
+
+
+
+
+
+
+function fromNumber(z, n) {
+	n = Math.trunc(n);
+	var neg = n < 0;
+	if (neg) {
+		n = -n;
+	}
+
+	z.hi = Math.floor(n / two32) % two32;
+	z.lo = n % two32;
+
+	if (neg) {
+		return sub64(z, new Uint64(0, 0), z);
+	}
+	return z;
+}
+
+
+function add64(z, x, y) {
+	var hi = x.hi + y.hi;
+	var lo = x.lo + y.lo;
+
+	if (lo >= two32) {
+		lo -= two32;
+		hi++;
+	}
+	if (hi >= two32) {
+		hi -= two32;
+	}
+
+	z.hi = hi;
+	z.lo = lo;
+	return z;
+}
+
+
+function sub64(z, x, y) {
+	var hi = x.hi - y.hi;
+	var lo = x.lo - y.lo;
+
+	if (lo < 0) {
+		lo += two32;
+		hi--;
+	}
+	if (hi < 0) {
+		hi += two32;
+	}
+
+	z.hi = hi;
+	z.lo = lo;
+	return z;
+}
+
+
+
+function mul64(z, x, y) {
+	var x48 = Math.floor(x.hi / two16);
+	var x32 = x.hi % two16;
+	var x16 = Math.floor(x.lo / two16);
+	var x00 = x.lo % two16;
+
+	var y48 = Math.floor(y.hi / two16);
+	var y32 = y.hi % two16;
+	var y16 = Math.floor(y.lo / two16);
+	var y00 = y.lo % two16;
+
+	var z00 = x00 * y00;
+	var z16 = Math.floor(z00 / two16) + x16 * y00;
+	z00 = z00 % two16;
+	var z32 = Math.floor(z16 / two16);
+	z16 = z16 % two16 + x00 * y16;
+	z32 += Math.floor(z16 / two16) + x32 * y00;
+	z16 = z16 % two16;
+	var z48 = Math.floor(z32 / two16);
+	z32 = z32 % two16 + x16 * y16;
+	z48 += Math.floor(z32 / two16);
+	z32 = z32 % two16 + x00 * y32;
+	z48 += Math.floor(z32 / two16);
+	z32 = z32 % two16;
+	z48 = (z48 + x48 * y00 + x32 * y16 + x16 * y32 + x00 * y48) % two16;
+
+	z.hi = z48 * two16 + z32;
+	z.lo = z16 * two16 + z00;
+	return z;
+}
+
+
+
+function divmod64(q, r, x, y) {
+	if (y.hi === 0 && y.lo === 0) {
+		throw new Error("runtime error: integer divide by zero");
+	}
+
+
+	if (y.hi === 0 && y.lo < 2097152) {
+		q.hi = Math.floor(x.hi / y.lo);
+		var t = (x.hi % y.lo) * two32 + x.lo;
+		q.lo = Math.floor(t / y.lo);
+		r.hi = 0;
+		r.lo = t % y.lo;
+		return;
+	}
+
+
+	var qh = 0, ql = 0, rh = 0, rl = 0;
+
+	for (var i = 63; i >= 0; i--) {
+		var bit = 0;
+		if (i >= 32) {
+			bit = Math.floor(x.hi / Math.pow(2, i - 32)) % 2;
+		} else {
+			bit = Math.floor(x.lo / Math.pow(2, i)) % 2;
+		}
+
+
+		rh = rh * 2 + Math.floor(rl / two31);
+		rl = rl % two31 * 2 + bit;
+		qh = qh % two31 * 2 + Math.floor(ql / two31);
+		ql = ql % two31 * 2;
+
+		if (rh > y.hi || rh >= y.hi && rl >= y.lo) {
+			rh -= y.hi;
+			rl -= y.lo;
+			if (rl < 0) {
+				rl += two32;
+				rh--;
+			}
+			ql++;
+		}
+	}
+
+	q.hi = qh;
+	q.lo = ql;
+	r.hi = rh;
+	r.lo = rl;
+}
+
+
+function u32(n) {
+	if (n < 0) {
+		return n + two32;
+	}
+	return n;
+}
+
+
+function and64(z, x, y) {
+	z.hi = u32(x.hi & y.hi);
+	z.lo = u32(x.lo & y.lo);
+	return z;
+}
+
+
+function or64(z, x, y) {
+	z.hi = u32(x.hi | y.hi);
+	z.lo = u32(x.lo | y.lo);
+	return z;
+}
+
+
+function xor64(z, x, y) {
+	z.hi = u32(x.hi ^ y.hi);
+	z.lo = u32(x.lo ^ y.lo);
+	return z;
+}
+
+
+function andNot64(z, x, y) {
+	z.hi = u32(x.hi & ~y.hi);
+	z.lo = u32(x.lo & ~y.lo);
+	return z;
+}
+
+
+function not64(z, x) {
+	z.hi = two32 - 1 - x.hi;
+	z.lo = two32 - 1 - x.lo;
+	return z;
+}
+
+
+function shl64(z, x, n) {
+	if (n < 0) {
+		throw new Error("runtime error: negative shift amount");
+	}
+
+	if (n >= 64) {
+		z.hi = 0;
+		z.lo = 0;
+	} else if (n >= 32) {
+		z.hi = x.lo * Math.pow(2, n - 32) % two32;
+		z.lo = 0;
+	} else {
+		z.hi = x.hi * Math.pow(2, n) % two32 + Math.floor(x.lo / Math.pow(2, 32 - n));
+		z.lo = x.lo * Math.pow(2, n) % two32;
+	}
+	return z;
+}
+
+
+function shr64(z, x, n) {
+	if (n < 0) {
+		throw new Error("runtime error: negative shift amount");
+	}
+
+	if (n >= 64) {
+		z.hi = 0;
+		z.lo = 0;
+	} else if (n >= 32) {
+		z.hi = 0;
+		z.lo = Math.floor(x.hi / Math.pow(2, n - 32));
+	} else {
+		z.lo = Math.floor(x.lo / Math.pow(2, n)) + x.hi % Math.pow(2, n) * Math.pow(2, 32 - n);
+		z.hi = Math.floor(x.hi / Math.pow(2, n));
+	}
+	return z;
+}
+
+
+function cmp64(xh, xl, yh, yl) {
+	if (xh < yh) {
+		return -1;
+	}
+	if (xh > yh) {
+		return 1;
+	}
+	if (xl < yl) {
+		return -1;
+	}
+	if (xl > yl) {
+		return 1;
+	}
+	return 0;
+}
+
+
+
+function utoa(x) {
+	var s = "";
+
+	for (; x.hi !== 0;) {
+		var q = new Uint64(0, 0);
+		var r = new Uint64(0, 0);
+		divmod64(q, r, x, new Uint64(0, 1000000));
+
+		s = String(r.lo + 1000000).slice(1) + s;
+		x = q;
+	}
+	return String(x.lo) + s;
+}
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+export function Complex(re, im) {
+	this.re=re;
+	this.im=im;
+}
+
+Complex.prototype.add = function(y) { return new Complex(this.re + y.re, this.im + y.im); }
+Complex.prototype.sub = function(y) { return new Complex(this.re - y.re, this.im - y.im); }
+Complex.prototype.neg = function() { return new Complex(-this.re, -this.im); }
+
+
+Complex.prototype.mul = function(y) {
+	return new Complex(this.re * y.re - this.im * y.im, this.re * y.im + this.im * y.re);
+}
+
+
+
+Complex.prototype.div = function(y) {
+	var re = 0, im = 0;
+
+
+	if (Math.abs(y.re) >= Math.abs(y.im)) {
+		var ratio = y.im / y.re;
+		var denom = y.re + ratio * y.im;
+		re = (this.re + this.im * ratio) / denom;
+		im = (this.im - this.re * ratio) / denom;
+	} else {
+		var ratio = y.re / y.im;
+		var denom = y.im + ratio * y.re;
+		re = (this.re * ratio + this.im) / denom;
+		im = (this.im * ratio - this.re) / denom;
+	}
+
+
+	if (isNaN(re) && isNaN(im) && y.re === 0 && y.im === 0 && (!isNaN(this.re) || !isNaN(this.im))) {
+		var inf = Infinity;
+		if (1 / y.re < 0) {
+			inf = -Infinity;
+		}
+		re = inf * this.re;
+		im = inf * this.im;
+	}
+	return new Complex(re, im);
+}
+
+
+
+
+Complex.prototype.eq = function(y) {
+	return this.re <= y.re && this.re >= y.re && this.im <= y.im && this.im >= y.im;
+}
+
+
+Complex.prototype.fround = function() { return new Complex(Math.fround(this.re), Math.fround(this.im)); }
+
+
+
+Complex.prototype.toString = function(size) {
+	var im = FormatFloat(this.im, size);
+	var sign = im.charAt(0); if (sign !== "-" && sign !== "+") {
+		im = "+" + im;
+	}
+	return "(" + FormatFloat(this.re, size) + im + "i)";
+}
+
+
+
+
+export function FormatFloat(f, size) {
+	switch (true) {
+	case isNaN(f):
+		return "NaN";
+	case f > Number.MAX_VALUE:
+		return "+Inf";
+	case f < -Number.MAX_VALUE:
+		return "-Inf";
+	case f === 0 && 1 / f < 0:
+		return "-0";
+	}
+
+	if (size === 32) {
+		for (var p = 1; p < 9; p++) {
+			var d = Number(f.toPrecision(p)); if (Math.fround(d) <= f && Math.fround(d) >= f) {
+				f = d;
+				break;
+			}
+		}
+	}
+
+	var s = f.toExponential();
+	var exp = Number(s.slice(s.indexOf("e") + 1));
+	if (exp >= -4 && exp < 6) {
+		return String(f);
+	}
+
+
+	var mant = s.slice(0, s.indexOf("e") + 2);
+	if (Math.abs(exp) < 10) {
+		mant += "0";
+	}
+	return mant + String(Math.abs(exp));
+}
+
+
+
+
+
+export function CmplxAbs(x) { return Math.hypot(x.re, x.im); }
+
+
+export function CmplxConj(x) { return new Complex(x.re, -x.im); }
+
+
+export function CmplxPhase(x) { return Math.atan2(x.im, x.re); }
+
+
+export function CmplxPolar(x) { return [CmplxAbs(x), CmplxPhase(x)]; }
+
+
+export function CmplxRect(r, θ) { return new Complex(r * Math.cos(θ), r * Math.sin(θ)); }
+
+
+export function CmplxInf() { return new Complex(Infinity, Infinity); }
+
+
+export function CmplxNaN() { return new Complex(NaN, NaN); }
+
+
+export function CmplxIsInf(x) {
+	return Math.abs(x.re) > Number.MAX_VALUE || Math.abs(x.im) > Number.MAX_VALUE;
+}
+
+
+export function CmplxIsNaN(x) {
+	return !CmplxIsInf(x) && (isNaN(x.re) || isNaN(x.im));
+}
+
+
+export function CmplxExp(x) {
+	var r = Math.exp(x.re);
+	return new Complex(r * Math.cos(x.im), r * Math.sin(x.im));
+}
+
+
+export function CmplxLog(x) {
+	return new Complex(Math.log(CmplxAbs(x)), CmplxPhase(x));
+}
+
+
+export function CmplxPow(x, y) {
+	if (x.re === 0 && x.im === 0) {
+		switch (true) {
+		case CmplxIsNaN(y):
+			return CmplxNaN();
+		case y.re === 0:
+			return new Complex(1, 0);
+		case y.re < 0:
+			if (y.im === 0) {
+			return new Complex(Infinity, 0);
+		}
+			return CmplxInf();
+		}
+		return new Complex(0, 0);
+	}
+
+	var modulus = CmplxAbs(x);
+	var r = Math.pow(modulus, y.re);
+	var arg = CmplxPhase(x);
+	var theta = y.re * arg;
+
+	if (y.im !== 0) {
+		r *= Math.exp(-y.im * arg);
+		theta += y.im * Math.log(modulus);
+	}
+	return new Complex(r * Math.cos(theta), r * Math.sin(theta));
+}
+
+
+export function CmplxSqrt(x) {
+	if (x.im === 0) {
+		switch (true) {
+		case x.re === 0:
+			return new Complex(0, x.im);
+		case x.re < 0:
+			if (1 / x.im < 0) {
+			return new Complex(0, -Math.sqrt(-x.re));
+		}
+			return new Complex(0, Math.sqrt(-x.re));
+		}
+		return new Complex(Math.sqrt(x.re), x.im);
+	}
+
+	if (Math.abs(x.im) > Number.MAX_VALUE) {
+		return new Complex(Infinity, x.im);
+	}
+	if (x.re === 0) {
+		if (x.im < 0) {
+			var r = Math.sqrt(-0.5 * x.im);
+			return new Complex(r, -r);
+		}
+		var r = Math.sqrt(0.5 * x.im);
+		return new Complex(r, r);
+	}
+
+
+	var a = x.re, b = x.im, scale = 0;
+	if (Math.abs(a) > 4 || Math.abs(b) > 4) {
+		a *= 0.25;
+		b *= 0.25;
+		scale = 2;
+	} else {
+		a *= 1.8014398509481984e16;
+		b *= 1.8014398509481984e16;
+		scale = 7.450580596923828125e-9;
+	}
+
+	var r = Math.hypot(a, b);
+	var t = 0;
+	if (a > 0) {
+		t = Math.sqrt(0.5 * r + 0.5 * a);
+		r = scale * Math.abs((0.5 * b) / t);
+		t *= scale;
+	} else {
+		r = Math.sqrt(0.5 * r - 0.5 * a);
+		t = scale * Math.abs((0.5 * b) / r);
+		r *= scale;
+	}
+
+	if (b < 0) {
+		return new Complex(t, -r);
+	}
+	return new Complex(t, r);
+}
+
+
+export function CmplxSin(x) {
+	return new Complex(Math.sin(x.re) * Math.cosh(x.im), Math.cos(x.re) * Math.sinh(x.im));
+}
+
+
+export function CmplxCos(x) {
+	return new Complex(Math.cos(x.re) * Math.cosh(x.im), -Math.sin(x.re) * Math.sinh(x.im));
+}
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+const runeError = 0xFFFD;
+
+
+
+export function DecodeRune(s, i) {
+	var c = s.charCodeAt(i);
+	if (c < 0x80) {
+		return [c, 1];
+	}
+
+	var n = 0, min = 0, r = 0;
+	switch (true) {
+	case c >= 0xC2 && c < 0xE0:
+		n = 1;
+		min = 0x80;
+		r = c & 0x1F; break;
+	case c >= 0xE0 && c < 0xF0:
+		n = 2;
+		min = 0x800;
+		r = c & 0x0F; break;
+	case c >= 0xF0 && c < 0xF5:
+		n = 3;
+		min = 0x10000;
+		r = c & 0x07; break;
+	default:
+		return [runeError, 1];
+	}
+
+	for (var j = 1; j <= n; j++) {
+
+		var b = s.charCodeAt(i + j);
+		if ((b & 0xC0) !== 0x80) {
+			return [runeError, 1];
+		}
+		r = r << 6 | b & 0x3F;
+	}
+
+	if (r < min || r >= 0xD800 && r < 0xE000 || r > 0x10FFFF) {
+		return [runeError, 1];
+	}
+	return [r, n + 1];
+}
+
+
+
+export function EncodeRune(r) {
+	if (r < 0 || r > 0x10FFFF || r >= 0xD800 && r < 0xE000) {
+		r = runeError;
+	}
+
+	switch (true) {
+	case r < 0x80:
+		return String.fromCharCode(r);
+	case r < 0x800:
+		return String.fromCharCode(0xC0 | r >> 6, 0x80 | r & 0x3F);
+	case r < 0x10000:
+		return String.fromCharCode(0xE0 | r >> 12, 0x80 | r >> 6 & 0x3F, 0x80 | r & 0x3F);
+	}
+	return String.fromCharCode(0xF0 | r >> 18, 0x80 | r >> 12 & 0x3F, 0x80 | r >> 6 & 0x3F, 0x80 | r & 0x3F);
+}
+
+
+
+export function Decode(s) {
+	s = String(s);
+	var text = "";
+
+	for (var i = 0; i < s.length;) {
+		var _ = DecodeRune(s, i), r = _[0], n = _[1];
+		text += String.fromCodePoint(r);
+		i += n;
+	}
+	return text;
+}
+
+
+export function Encode(text) {
+	var s = "";
+
+	for (var i = 0; i < text.length; i++) {
+		var r = text.codePointAt(i);
+		if (r > 0xFFFF) {
+			i++;
+		}
+		s += EncodeRune(r);
+	}
+	return s;
+}
+
+
+
+
+
+export function StringToBytes(s) {
+	var b = Array();
+	for (var i = 0; i < s.length; i++) {
+		b.push(s.charCodeAt(i));
+	}
+	return NewSlice(b, 0);
+}
+
+
+export function StringToRunes(s) {
+	var runes = Array();
+	for (var i = 0; i < s.length;) {
+		var _ = DecodeRune(s, i), r = _[0], n = _[1];
+		runes.push(r);
+		i += n;
+	}
+	return NewSlice(runes, 0);
+}
+
+
+export function BytesToString(b) {
+	var s = "";
+	for (var i = 0; i < b.len; i++) {
+		s += String.fromCharCode(b.f[i] & 0xFF);
+	}
+	return s;
+}
+
+
+export function RunesToString(runes) {
+	var s = "";
+	for (var i = 0; i < runes.len; i++) {
+		s += EncodeRune(runes.f[i]);
+	}
+	return s;
+}
+//# sourceMappingURL=pkg.js.map
@@ -0,0 +1,168 @@
+// Copyright 2012  The "GoScript" Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Handle the interfaces.
+
+package g
+
+// A value of an interface is nil, "undefined", or a box "I" which stores the
+// dynamic value and its type. Each type has a descriptor at run time, unique
+// for its name, which is got through "Type" or "Iface".
+//
+// The boxes of a type are created from a prototype which has the methods of
+// the type; each method calls the one of the value, so the methods of an
+// interface are called like any other. The values of the named types which
+// are not structs have not methods, so their boxes call the functions which
+// implement them, with the value like the receiver.
+
+// rtype represents a type at run time.
+type rtype struct {
+	str     string      // the type, like it is written in Go
+	eq      interface{} // function which compares two values; nil to use "==="
+	methods []string    // methods with their signatures, like "Area() float64"
+	iface   bool        // is it an interface?
+	proto   interface{} // prototype of the boxes, with the methods
+}
+
+var typesByName = Reflect.construct(Map, Array()) // descriptors by string
+
+// Uncomparable is the function "eq" of the types whose values can not be
+// compared, like the slices, maps and functions.
+func Uncomparable(x, y interface{}) bool {
+	panic("unreachable")
+}
+
+// Returns the descriptor of the type "str", whose values are compared by the
+// function eq and have the methods; it is created the first time. The methods
+// are implemented by the functions "funcs" by name, if any.
+func Type(str string, eq interface{}, methods []string, funcs interface{}) *rtype {
+	t := typesByName.get(str)
+	if t != nil {
+		return t
+	}
+	if methods == nil {
+		methods = Array()
+	}
+
+	t = rtype{str, eq, methods, false, Object.create(I.prototype)}
+	for i := 0; i < len(methods); i++ {
+		name := methods[i].slice(0, methods[i].indexOf("("))
+		if funcs != nil {
+			t.proto[name] = forwardFunc(funcs[name])
+		} else {
+			t.proto[name] = forward(name)
+		}
+	}
+
+	typesByName.set(str, t)
+	return t
+}
+
+// Returns the descriptor of the interface type "str", whose dynamic types
+// have to have the methods; it is created the first time.
+func Iface(str string, methods []string) *rtype {
+	t := typesByName.get(str)
+	if t == nil {
+		t = rtype{str, nil, methods, true, nil}
+		typesByName.set(str, t)
+	}
+	return t
+}
+
+// Returns a method which calls the method "name" of the value boxed.
+func forward(name string) interface{} {
+	method := func() interface{} {
+		return this.v[name].apply(this.v, arguments)
+	}
+	return method
+}
+
+// Returns the method of the boxes which calls the function f, with the value
+// boxed like the receiver.
+func forwardFunc(f interface{}) interface{} {
+	method := func() interface{} {
+		return CallFunc(f, this.v, arguments)
+	}
+	return method
+}
+
+// I represents a value of an interface which is not nil.
+type I struct {
+	v interface{} // dynamic value
+	t interface{} // dynamic type, a descriptor "rtype"
+}
+
+// Returns the value v of type t stored in an interface.
+func Box(v interface{}, t *rtype) *I {
+	x := Object.create(t.proto)
+	x.v = v
+	x.t = t
+	return x
+}
+
+// The value boxed is used to print it.
+func (x I) toString() string  { return String(x.v) }
+func (x I) valueOf() interface{} { return x.v }
+
+// == Assertions
+//
+
+// Reports whether the value x of an interface has the type t; if t is an
+// interface, whether the dynamic type of x has its methods.
+func Is(x *I, t *rtype) bool {
+	if x == nil {
+		return false
+	}
+	if !t.iface {
+		return Object.is(x.t, t)
+	}
+
+	for i := 0; i < len(t.methods); i++ {
+		if !x.t.methods.includes(t.methods[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the value of the interface x like a value of type t: the dynamic
+// value, or the same box if t is an interface.
+// It panics if x has not the type t.
+func Assert(x *I, t *rtype) interface{} {
+	if !Is(x, t) {
+		dynamic := "nil"
+		if x != nil {
+			dynamic = x.t.str
+		}
+		panic("interface conversion: interface is " + dynamic + ", not " + t.str)
+	}
+
+	if t.iface {
+		return x
+	}
+	return x.v
+}
+
+// Returns the value of the interface x like a value of type t, and reports
+// whether x has that type. If it has not it, then it returns the zero value.
+func AssertOk(x *I, t *rtype, zero interface{}) (interface{}, bool) {
+	if !Is(x, t) {
+		return zero, false
+	}
+
+	if t.iface {
+		return x, true
+	}
+	return x.v, true
+}
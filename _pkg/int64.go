@@ -0,0 +1,436 @@
+// Copyright 2012  The "GoScript" Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Handle the integers of 64 bits.
+
+package g
+
+// A number of JavaScript only represents exactly the integers until 2^53, so
+// the integers of 64 bits are stored in their high and low 32 bits, both like
+// unsigned numbers. The values are immutable; every operation returns a new one.
+//
+// The bitwise operators of JavaScript return signed integers of 32 bits, and
+// its shifts only use the 5 lower bits of the count; then, the words are
+// handled by means of arithmetic, which is exact until 2^53.
+
+const (
+	two16 = 65536
+	two31 = 2147483648
+	two32 = 4294967296
+)
+
+// Int64 represents an int64.
+type Int64 struct {
+	hi uint32 // high 32 bits
+	lo uint32 // low 32 bits
+}
+
+// Uint64 represents an uint64.
+type Uint64 struct {
+	hi uint32
+	lo uint32
+}
+
+// Returns the integer n, truncated towards zero, like an Int64.
+func ToInt64(n float64) Int64 {
+	return fromNumber(Int64{0, 0}, n)
+}
+
+// Returns the integer n, truncated towards zero, like an Uint64.
+func ToUint64(n float64) Uint64 {
+	return fromNumber(Uint64{0, 0}, n)
+}
+
+// == Int64
+//
+
+func (x Int64) add(y Int64) Int64    { return add64(Int64{0, 0}, x, y) }
+func (x Int64) sub(y Int64) Int64    { return sub64(Int64{0, 0}, x, y) }
+func (x Int64) mul(y Int64) Int64    { return mul64(Int64{0, 0}, x, y) }
+func (x Int64) and(y Int64) Int64    { return and64(Int64{0, 0}, x, y) }
+func (x Int64) or(y Int64) Int64     { return or64(Int64{0, 0}, x, y) }
+func (x Int64) xor(y Int64) Int64    { return xor64(Int64{0, 0}, x, y) }
+func (x Int64) andNot(y Int64) Int64 { return andNot64(Int64{0, 0}, x, y) }
+func (x Int64) shl(n uint) Int64     { return shl64(Int64{0, 0}, x, n) }
+func (x Int64) not() Int64           { return not64(Int64{0, 0}, x) }
+func (x Int64) neg() Int64           { return sub64(Int64{0, 0}, Int64{0, 0}, x) }
+
+// Returns x >> n, with sign extension.
+func (x Int64) shr(n uint) Int64 {
+	if x.hi < two31 {
+		return shr64(Int64{0, 0}, x, n)
+	}
+	return shr64(Int64{0, 0}, x.not(), n).not()
+}
+
+// Returns the quotient x / y, truncated towards zero.
+func (x Int64) div(y Int64) Int64 {
+	q := Int64{0, 0}
+	divmod64(q, Int64{0, 0}, x.abs(), y.abs())
+
+	neg := x.hi >= two31
+	if y.hi >= two31 {
+		neg = !neg
+	}
+	if neg {
+		return q.neg()
+	}
+	return q
+}
+
+// Returns the remainder x % y, which has the sign of x.
+func (x Int64) rem(y Int64) Int64 {
+	r := Int64{0, 0}
+	divmod64(Int64{0, 0}, r, x.abs(), y.abs())
+
+	if x.hi >= two31 {
+		return r.neg()
+	}
+	return r
+}
+
+// Returns the absolute value of x; the minimum value is not changed, but its
+// bits are the ones of its absolute value like an unsigned integer.
+func (x Int64) abs() Int64 {
+	if x.hi >= two31 {
+		return x.neg()
+	}
+	return x
+}
+
+// Returns the high 32 bits like a signed integer.
+func (x Int64) high() int {
+	if x.hi >= two31 {
+		return x.hi - two32
+	}
+	return x.hi
+}
+
+// Compares x and y, and returns -1 if x < y, 0 if x == y, and +1 if x > y.
+func (x Int64) cmp(y Int64) int { return cmp64(x.high(), x.lo, y.high(), y.lo) }
+
+// Reports whether x == y.
+func (x Int64) eq(y Int64) bool { return x.cmp(y) == 0 }
+
+// Returns the value like a number, which could be rounded.
+func (x Int64) toNumber() float64 { return x.high()*two32 + x.lo }
+
+func (x Int64) toUint64() Uint64 { return Uint64{x.hi, x.lo} }
+
+// Returns the value in decimal, like Go prints it.
+func (x Int64) toString() string {
+	if x.hi >= two31 {
+		return "-" + utoa(x.neg())
+	}
+	return utoa(x)
+}
+
+// == Uint64
+//
+
+func (x Uint64) add(y Uint64) Uint64    { return add64(Uint64{0, 0}, x, y) }
+func (x Uint64) sub(y Uint64) Uint64    { return sub64(Uint64{0, 0}, x, y) }
+func (x Uint64) mul(y Uint64) Uint64    { return mul64(Uint64{0, 0}, x, y) }
+func (x Uint64) and(y Uint64) Uint64    { return and64(Uint64{0, 0}, x, y) }
+func (x Uint64) or(y Uint64) Uint64     { return or64(Uint64{0, 0}, x, y) }
+func (x Uint64) xor(y Uint64) Uint64    { return xor64(Uint64{0, 0}, x, y) }
+func (x Uint64) andNot(y Uint64) Uint64 { return andNot64(Uint64{0, 0}, x, y) }
+func (x Uint64) shl(n uint) Uint64      { return shl64(Uint64{0, 0}, x, n) }
+func (x Uint64) shr(n uint) Uint64      { return shr64(Uint64{0, 0}, x, n) }
+func (x Uint64) not() Uint64            { return not64(Uint64{0, 0}, x) }
+func (x Uint64) neg() Uint64            { return sub64(Uint64{0, 0}, Uint64{0, 0}, x) }
+
+// Returns the quotient x / y.
+func (x Uint64) div(y Uint64) Uint64 {
+	q := Uint64{0, 0}
+	divmod64(q, Uint64{0, 0}, x, y)
+	return q
+}
+
+// Returns the remainder x % y.
+func (x Uint64) rem(y Uint64) Uint64 {
+	r := Uint64{0, 0}
+	divmod64(Uint64{0, 0}, r, x, y)
+	return r
+}
+
+// Compares x and y, and returns -1 if x < y, 0 if x == y, and +1 if x > y.
+func (x Uint64) cmp(y Uint64) int { return cmp64(x.hi, x.lo, y.hi, y.lo) }
+
+// Reports whether x == y.
+func (x Uint64) eq(y Uint64) bool { return x.cmp(y) == 0 }
+
+// Returns the value like a number, which could be rounded.
+func (x Uint64) toNumber() float64 { return x.hi*two32 + x.lo }
+
+func (x Uint64) toInt64() Int64 { return Int64{x.hi, x.lo} }
+
+// Returns the value in decimal, like Go prints it.
+func (x Uint64) toString() string { return utoa(x) }
+
+// == Operations
+//
+// The functions set the result in z, which is returned, and they are used by
+// both types since only the bits are handled.
+
+// Sets z to the integer n.
+func fromNumber(z interface{}, n float64) interface{} {
+	n = Math.trunc(n)
+	neg := n < 0
+	if neg {
+		n = -n
+	}
+
+	z.hi = Math.floor(n/two32) % two32
+	z.lo = n % two32
+
+	if neg {
+		return sub64(z, Uint64{0, 0}, z)
+	}
+	return z
+}
+
+// Sets z to x + y.
+func add64(z, x, y interface{}) interface{} {
+	hi := x.hi + y.hi
+	lo := x.lo + y.lo
+
+	if lo >= two32 {
+		lo -= two32
+		hi++
+	}
+	if hi >= two32 {
+		hi -= two32
+	}
+
+	z.hi = hi
+	z.lo = lo
+	return z
+}
+
+// Sets z to x - y.
+func sub64(z, x, y interface{}) interface{} {
+	hi := x.hi - y.hi
+	lo := x.lo - y.lo
+
+	if lo < 0 {
+		lo += two32
+		hi--
+	}
+	if hi < 0 {
+		hi += two32
+	}
+
+	z.hi = hi
+	z.lo = lo
+	return z
+}
+
+// Sets z to x * y.
+// The words are split in 16 bits, so the products are exact.
+func mul64(z, x, y interface{}) interface{} {
+	x48 := Math.floor(x.hi / two16)
+	x32 := x.hi % two16
+	x16 := Math.floor(x.lo / two16)
+	x00 := x.lo % two16
+
+	y48 := Math.floor(y.hi / two16)
+	y32 := y.hi % two16
+	y16 := Math.floor(y.lo / two16)
+	y00 := y.lo % two16
+
+	z00 := x00 * y00
+	z16 := Math.floor(z00/two16) + x16*y00
+	z00 = z00 % two16
+	z32 := Math.floor(z16 / two16)
+	z16 = z16%two16 + x00*y16
+	z32 += Math.floor(z16/two16) + x32*y00
+	z16 = z16 % two16
+	z48 := Math.floor(z32 / two16)
+	z32 = z32%two16 + x16*y16
+	z48 += Math.floor(z32 / two16)
+	z32 = z32%two16 + x00*y32
+	z48 += Math.floor(z32 / two16)
+	z32 = z32 % two16
+	z48 = (z48 + x48*y00 + x32*y16 + x16*y32 + x00*y48) % two16
+
+	z.hi = z48*two16 + z32
+	z.lo = z16*two16 + z00
+	return z
+}
+
+// Sets q to the quotient x / y, and r to the remainder x % y, like unsigned
+// integers.
+func divmod64(q, r, x, y interface{}) {
+	if y.hi == 0 && y.lo == 0 {
+		panic("runtime error: integer divide by zero")
+	}
+
+	// The remainders of a divisor of 21 bits, by 2^32, are exact.
+	if y.hi == 0 && y.lo < 2097152 {
+		q.hi = Math.floor(x.hi / y.lo)
+		t := (x.hi%y.lo)*two32 + x.lo
+		q.lo = Math.floor(t / y.lo)
+		r.hi = 0
+		r.lo = t % y.lo
+		return
+	}
+
+	// Long division, bit by bit.
+	qh, ql, rh, rl := 0, 0, 0, 0
+
+	for i := 63; i >= 0; i-- {
+		bit := 0
+		if i >= 32 {
+			bit = Math.floor(x.hi/Math.pow(2, i-32)) % 2
+		} else {
+			bit = Math.floor(x.lo/Math.pow(2, i)) % 2
+		}
+
+		// The remainder could have 65 bits until it is subtracted.
+		rh = rh*2 + Math.floor(rl/two31)
+		rl = rl%two31*2 + bit
+		qh = qh%two31*2 + Math.floor(ql/two31)
+		ql = ql % two31 * 2
+
+		if rh > y.hi || rh >= y.hi && rl >= y.lo {
+			rh -= y.hi
+			rl -= y.lo
+			if rl < 0 {
+				rl += two32
+				rh--
+			}
+			ql++
+		}
+	}
+
+	q.hi = qh
+	q.lo = ql
+	r.hi = rh
+	r.lo = rl
+}
+
+// Returns n like an unsigned integer of 32 bits.
+func u32(n int32) uint32 {
+	if n < 0 {
+		return n + two32
+	}
+	return n
+}
+
+// Sets z to x & y.
+func and64(z, x, y interface{}) interface{} {
+	z.hi = u32(x.hi & y.hi)
+	z.lo = u32(x.lo & y.lo)
+	return z
+}
+
+// Sets z to x | y.
+func or64(z, x, y interface{}) interface{} {
+	z.hi = u32(x.hi | y.hi)
+	z.lo = u32(x.lo | y.lo)
+	return z
+}
+
+// Sets z to x ^ y.
+func xor64(z, x, y interface{}) interface{} {
+	z.hi = u32(x.hi ^ y.hi)
+	z.lo = u32(x.lo ^ y.lo)
+	return z
+}
+
+// Sets z to x &^ y.
+func andNot64(z, x, y interface{}) interface{} {
+	z.hi = u32(x.hi &^ y.hi)
+	z.lo = u32(x.lo &^ y.lo)
+	return z
+}
+
+// Sets z to ^x.
+func not64(z, x interface{}) interface{} {
+	z.hi = two32 - 1 - x.hi
+	z.lo = two32 - 1 - x.lo
+	return z
+}
+
+// Sets z to x << n.
+func shl64(z, x interface{}, n uint) interface{} {
+	if n < 0 {
+		panic("runtime error: negative shift amount")
+	}
+
+	if n >= 64 {
+		z.hi = 0
+		z.lo = 0
+	} else if n >= 32 {
+		z.hi = x.lo * Math.pow(2, n-32) % two32
+		z.lo = 0
+	} else {
+		z.hi = x.hi*Math.pow(2, n)%two32 + Math.floor(x.lo/Math.pow(2, 32-n))
+		z.lo = x.lo * Math.pow(2, n) % two32
+	}
+	return z
+}
+
+// Sets z to x >> n, without sign extension.
+func shr64(z, x interface{}, n uint) interface{} {
+	if n < 0 {
+		panic("runtime error: negative shift amount")
+	}
+
+	if n >= 64 {
+		z.hi = 0
+		z.lo = 0
+	} else if n >= 32 {
+		z.hi = 0
+		z.lo = Math.floor(x.hi / Math.pow(2, n-32))
+	} else {
+		z.lo = Math.floor(x.lo/Math.pow(2, n)) + x.hi%Math.pow(2, n)*Math.pow(2, 32-n)
+		z.hi = Math.floor(x.hi / Math.pow(2, n))
+	}
+	return z
+}
+
+// Compares the integers with the words (xh, xl) and (yh, yl).
+func cmp64(xh, xl, yh, yl int) int {
+	if xh < yh {
+		return -1
+	}
+	if xh > yh {
+		return 1
+	}
+	if xl < yl {
+		return -1
+	}
+	if xl > yl {
+		return 1
+	}
+	return 0
+}
+
+// Returns the unsigned integer x in decimal.
+// It is divided by 10^6, whose remainders have 6 digits.
+func utoa(x interface{}) string {
+	s := ""
+
+	for x.hi != 0 {
+		q := Uint64{0, 0}
+		r := Uint64{0, 0}
+		divmod64(q, r, x, Uint64{0, 1000000})
+
+		s = String(r.lo+1000000).slice(1) + s
+		x = q
+	}
+	return String(x.lo) + s
+}
@@ -0,0 +1,182 @@
+// Copyright 2012  The "GoScript" Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Handle the maps.
+
+package g
+
+// A map stores its entries in a Map of JavaScript, indexed by the hash of
+// their keys; each entry is an array with the key and the value, so the keys
+// are got back at ranging.
+//
+// The keys which JavaScript compares like Go, the strings, numbers, booleans
+// and pointers, are their own hash. For the rest of types, the compiler passes
+// a function which builds a string from the parts of the key through "Hash".
+// A hash which is NaN is not equal to itself, like the floats NaN in Go.
+
+// M represents a map.
+type M struct {
+	f    interface{} // Map with the entries [key, value] by hash
+	zero interface{} // zero value for the map
+	hash interface{} // function which returns the hash of a key; or nil
+}
+
+// Returns a map whose values have the zero value "zero", with the entries
+// "kv", pairs of key and value. The keys are hashed by the function "hash",
+// if any.
+func NewMap(zero interface{}, kv [][]interface{}, hash func(k interface{}) interface{}) *M {
+	m := M{Reflect.construct(Map, Array()), zero, hash}
+	if kv != nil {
+		for i := 0; i < len(kv); i++ {
+			m.entry(kv[i][0])[1] = kv[i][1]
+		}
+	}
+	return m
+}
+
+// Returns an empty map whose values have the zero value "zero", to read a nil
+// map.
+func NilMap(zero interface{}) *M {
+	return NewMap(zero, nil, nil)
+}
+
+// Returns the hash of the key k.
+func (m M) key(k interface{}) interface{} {
+	if m.hash != nil {
+		return m.hash(k)
+	}
+	return k
+}
+
+// Gets the value for the key k, and reports whether it is in the map.
+// If the key is not found, then it returns the zero value.
+func (m M) get(k interface{}) (interface{}, bool) {
+	h := m.key(k)
+	if Number.isNaN(h) {
+		return m.zero, false
+	}
+
+	e := m.f.get(h)
+	if e == nil {
+		return m.zero, false
+	}
+	return e[1], true
+}
+
+// Returns the entry for the key k, which is added with the zero value if it
+// is not in the map. The value is assigned to the element 1 of the entry.
+func (m M) entry(k interface{}) []interface{} {
+	h := m.key(k)
+	if Number.isNaN(h) { // every NaN is a new key
+		h = Symbol()
+	}
+
+	e := m.f.get(h)
+	if e == nil {
+		e = Array(k, m.zero)
+		m.f.set(h, e)
+	}
+	return e
+}
+
+// Removes the entry for the key k, if any.
+func (m M) delete(k interface{}) {
+	m.f.delete(m.key(k))
+}
+
+// Returns the number of entries.
+func (m M) len() int {
+	return m.f.size
+}
+
+// == Hash
+//
+
+// Returns the hash of the parts of a key, which are basic values or arrays of
+// parts; or NaN if some one is NaN.
+func Hash(parts interface{}) interface{} {
+	nan := false
+	check := func(k, v interface{}) interface{} {
+		if Number.isNaN(v) {
+			nan = true
+		}
+		return v
+	}
+
+	h := JSON.stringify(parts, check)
+
+	if nan {
+		return NaN
+	}
+	return h
+}
+
+// Returns a number which identifies the object x, like a pointer; or 0 if it
+// is nil.
+func Id(x interface{}) int {
+	if x == nil {
+		return 0
+	}
+	if !ids.has(x) {
+		lastId++
+		ids.set(x, lastId)
+	}
+	return ids.get(x)
+}
+
+var (
+	ids    = Reflect.construct(WeakMap, Array()) // identifiers by object
+	lastId = 0
+)
+
+// Returns the parts of the value x of an interface, to be hashed: the dynamic
+// type and value. The structs and the objects identified by "Id" are stored in
+// objects, so they are not mixed up with the basic values or the arrays.
+func Key(x interface{}) interface{} {
+	if x == nil || !Object.is(Object(x), x) { // basic value
+		return x
+	}
+	if I.prototype.isPrototypeOf(x) { // box of a dynamic type
+		if Object.is(x.t.eq, Uncomparable) {
+			panic("runtime error: hash of unhashable type " + x.t.str)
+		}
+		return dynamicKey{Id(x.t), Key(x.v)}
+	}
+	if Array.isArray(x) {
+		parts := Array()
+		for i := 0; i < len(x); i++ {
+			parts.push(Key(x[i]))
+		}
+		return parts
+	}
+
+	// The structs have the method "eq", like the numbers of 64 bits and the
+	// complex numbers.
+	if x.eq != nil {
+		fields := Array()
+		names := Object.keys(x)
+		for i := 0; i < len(names); i++ {
+			fields.push(Key(x[names[i]]))
+		}
+		return dynamicKey{Id(x.constructor), fields}
+	}
+	return dynamicKey{Id(x), nil}
+}
+
+// dynamicKey represents the parts of an object which is the dynamic value of
+// an interface.
+type dynamicKey struct {
+	id     int         // the type of a box, the constructor of a struct, or the object
+	fields interface{} // parts of the value of a box, or of the fields of a struct
+}
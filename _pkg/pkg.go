@@ -23,6 +23,125 @@ func Export(pkg map[interface{}]interface{}, exported []interface{}) {
 	}
 }
 
+// == Functions
+//
+
+// Returns the value f of a function type, to call it.
+// It panics if f is nil.
+func Func(f interface{}) interface{} {
+	if f == nil {
+		panic("runtime error: invalid memory address or nil pointer dereference")
+	}
+	return f
+}
+
+// Returns the method "name" of x, bound to x.
+func Bind(x interface{}, name string) interface{} {
+	return x[name].bind(x)
+}
+
+// Returns the function of the method "name", which gets the receiver like
+// the first argument.
+func MethodExpr(name string) interface{} {
+	method := func(x interface{}) interface{} {
+		return x[name].apply(x, Array.prototype.slice.call(arguments, 1))
+	}
+	return method
+}
+
+// Calls the function f of a method of a named type which is not a struct,
+// with the receiver x and the arguments args.
+func CallFunc(f, x interface{}, args interface{}) interface{} {
+	return f.apply(nil, Array.of(x).concat(Array.prototype.slice.call(args)))
+}
+
+// Returns the function f of a method of a named type "T" which is not a
+// struct, which gets a pointer "*T" like the receiver.
+func Deref(f interface{}) interface{} {
+	method := func(x interface{}) interface{} {
+		return CallFunc(f, x.p, Array.prototype.slice.call(arguments, 1))
+	}
+	return method
+}
+
+// == Numbers
+//
+
+// Returns the quotient x / y of integers, truncated towards zero.
+// It panics if the divisor is zero.
+func Quo(x, y int) int {
+	if y == 0 {
+		panic("runtime error: integer divide by zero")
+	}
+	return Math.trunc(x / y)
+}
+
+// Returns the remainder x % y of integers.
+// It panics if the divisor is zero.
+func Rem(x, y int) int {
+	if y == 0 {
+		panic("runtime error: integer divide by zero")
+	}
+	return x % y
+}
+
+// Returns x << y for a shift count which is not constant.
+// The result has to be truncated to the size of the type.
+func Shl(x, y int) int {
+	if y < 0 {
+		panic("runtime error: negative shift amount")
+	}
+	if y >= 64 {
+		return 0
+	}
+	return x * Math.pow(2, y)
+}
+
+// Returns x >> y for a shift count which is not constant.
+func Shr(x, y int) int {
+	if y < 0 {
+		panic("runtime error: negative shift amount")
+	}
+	if y > 64 {
+		y = 64
+	}
+	return Math.floor(x / Math.pow(2, y))
+}
+
+// Returns the integer whose high and low 32 bits are hi and lo, the results of
+// a bitwise operator of JavaScript. The integers which are not truncated, like
+// int and uint, are operated by parts, since those operators are of 32 bits.
+func join32(hi, lo int) int {
+	if lo < 0 {
+		lo += 4294967296
+	}
+	return hi*4294967296 + lo
+}
+
+// Returns x & y.
+func And(x, y int) int {
+	xHi, yHi := Math.floor(x/4294967296), Math.floor(y/4294967296)
+	return join32(xHi&yHi, (x-xHi*4294967296)&(y-yHi*4294967296))
+}
+
+// Returns x | y.
+func Or(x, y int) int {
+	xHi, yHi := Math.floor(x/4294967296), Math.floor(y/4294967296)
+	return join32(xHi|yHi, (x-xHi*4294967296)|(y-yHi*4294967296))
+}
+
+// Returns x ^ y.
+func Xor(x, y int) int {
+	xHi, yHi := Math.floor(x/4294967296), Math.floor(y/4294967296)
+	return join32(xHi^yHi, (x-xHi*4294967296)^(y-yHi*4294967296))
+}
+
+// Returns x &^ y.
+func AndNot(x, y int) int {
+	xHi, yHi := Math.floor(x/4294967296), Math.floor(y/4294967296)
+	return join32(xHi&^yHi, (x-xHi*4294967296)&^(y-yHi*4294967296))
+}
+
 // == Slice
 //
 
@@ -36,7 +155,8 @@ type S struct {
 // Creates a new slice.
 func NewSlice(i interface{}, low, high int) *S {
 	s := new(S)
-	return s.set(i, low, high)
+	s.set(i, low, high)
+	return s
 }
 
 // Initializes a slice with the zero value.
@@ -57,6 +177,16 @@ func MakeSlice(zero interface{}, len, cap int) *S {
 	return s
 }
 
+// Returns an array of n elements, whose zero values are returned by the
+// function zero.
+func MakeArray(n int, zero func() interface{}) []interface{} {
+	a := Array(n)
+	for i := 0; i < n; i++ {
+		a[i] = zero()
+	}
+	return a
+}
+
 // Sets the slice.
 func (s S) set(i interface{}, low, high int) {
 	if i.f != nil { // slice
@@ -91,30 +221,40 @@ func (s S) isNil() bool {
 	return true
 }
 
-// == Map
+// == Equality
 //
 
-// M represents a map.
-// The compiler adds the appropriate zero value for the map (which it is work out
-// from the map type).
-type M struct {
-	f    map[interface{}]interface{} // map
-	zero interface{}                 // zero value for the map
+// Reports whether the arrays x and y have equal elements, compared by the
+// function eq.
+func ArrayEq(x, y []interface{}, eq func(a, b interface{}) bool) bool {
+	for i := 0; i < len(x); i++ {
+		if !eq(x[i], y[i]) {
+			return false
+		}
+	}
+	return true
 }
 
-// Gets the value for the key "k".
-// If looking some key up in M's map gets you "nil" ("undefined" in JS),
-// then return a copy of the zero value.
-func (m M) get(k interface{}) (interface{}, bool) {
-	v := m.f
-
-	// Allow multi-dimensional index (separated by commas)
-	for i := 0; i < len(arguments); i++ {
-		v = v[arguments[i]]
+// Reports whether the values x and y of interfaces are equal: they have the
+// same dynamic type, and their values are equal according to the function
+// "eq" of the type, or by identity.
+// It panics if the type is not comparable.
+func Equal(x, y *I) bool {
+	if x == nil || y == nil {
+		return Object.is(x, y)
+	}
+	if !Object.is(x.t, y.t) {
+		return false
+	}
+	if Object.is(x.t.eq, Uncomparable) {
+		panic("runtime error: comparing uncomparable type " + x.t.str)
+	}
+	if x.t.eq != nil {
+		return x.t.eq(x.v, y.v)
 	}
 
-	if v == nil {
-		return m.zero, false
+	if Number.isNaN(x.v) {
+		return false
 	}
-	return v, true
+	return Object.is(x.v, y.v) || x.v == 0 && y.v == 0
 }
@@ -0,0 +1,155 @@
+// Copyright 2012  The "GoScript" Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Handle the strings.
+
+package g
+
+// A string of Go is a sequence of bytes, which is stored in a string of
+// JavaScript whose characters are the bytes, from 0 to 255. Then, the length,
+// the indexes and the slices of a string are the ones of JavaScript.
+//
+// The text is decoded from UTF-8 before of being written, and it is encoded to
+// UTF-8 when it comes from JavaScript.
+
+// The replacement character U+FFFD, for the invalid encodings.
+const runeError = 0xFFFD
+
+// Returns the rune which starts at the byte i of the string s, and its size
+// in bytes. If the encoding is invalid, it returns (U+FFFD, 1).
+func DecodeRune(s string, i int) (rune, int) {
+	c := s.charCodeAt(i)
+	if c < 0x80 {
+		return c, 1
+	}
+
+	n, min, r := 0, 0, 0 // bytes of continuation, minimum value, rune
+	switch {
+	case c >= 0xC2 && c < 0xE0:
+		n = 1
+		min = 0x80
+		r = c & 0x1F
+	case c >= 0xE0 && c < 0xF0:
+		n = 2
+		min = 0x800
+		r = c & 0x0F
+	case c >= 0xF0 && c < 0xF5:
+		n = 3
+		min = 0x10000
+		r = c & 0x07
+	default:
+		return runeError, 1
+	}
+
+	for j := 1; j <= n; j++ {
+		// It is NaN out of the string, which is not a byte of continuation.
+		b := s.charCodeAt(i + j)
+		if b&0xC0 != 0x80 {
+			return runeError, 1
+		}
+		r = r<<6 | b&0x3F
+	}
+
+	if r < min || r >= 0xD800 && r < 0xE000 || r > 0x10FFFF {
+		return runeError, 1
+	}
+	return r, n + 1
+}
+
+// Returns the UTF-8 encoding of the rune r. If it is not a valid Unicode code
+// point, it returns the encoding of U+FFFD.
+func EncodeRune(r rune) string {
+	if r < 0 || r > 0x10FFFF || r >= 0xD800 && r < 0xE000 {
+		r = runeError
+	}
+
+	switch {
+	case r < 0x80:
+		return String.fromCharCode(r)
+	case r < 0x800:
+		return String.fromCharCode(0xC0|r>>6, 0x80|r&0x3F)
+	case r < 0x10000:
+		return String.fromCharCode(0xE0|r>>12, 0x80|r>>6&0x3F, 0x80|r&0x3F)
+	}
+	return String.fromCharCode(0xF0|r>>18, 0x80|r>>12&0x3F, 0x80|r>>6&0x3F, 0x80|r&0x3F)
+}
+
+// Returns the text of the string s, decoded from UTF-8.
+// Any other value is converted to string before.
+func Decode(s string) string {
+	s = String(s)
+	text := ""
+
+	for i := 0; i < len(s); {
+		r, n := DecodeRune(s, i)
+		text += String.fromCodePoint(r)
+		i += n
+	}
+	return text
+}
+
+// Returns the text of JavaScript, encoded to UTF-8.
+func Encode(text string) string {
+	s := ""
+
+	for i := 0; i < len(text); i++ {
+		r := text.codePointAt(i)
+		if r > 0xFFFF {
+			i++ // surrogate pair
+		}
+		s += EncodeRune(r)
+	}
+	return s
+}
+
+// == Conversions
+//
+
+// Returns the bytes of the string s, like a slice.
+func StringToBytes(s string) *S {
+	b := Array()
+	for i := 0; i < len(s); i++ {
+		b.push(s.charCodeAt(i))
+	}
+	return NewSlice(b, 0)
+}
+
+// Returns the runes of the string s, like a slice.
+func StringToRunes(s string) *S {
+	runes := Array()
+	for i := 0; i < len(s); {
+		r, n := DecodeRune(s, i)
+		runes.push(r)
+		i += n
+	}
+	return NewSlice(runes, 0)
+}
+
+// Returns the string with the bytes of the slice b.
+func BytesToString(b *S) string {
+	s := ""
+	for i := 0; i < b.len; i++ {
+		s += String.fromCharCode(b.f[i] & 0xFF)
+	}
+	return s
+}
+
+// Returns the string with the runes of the slice runes, encoded to UTF-8.
+func RunesToString(runes *S) string {
+	s := ""
+	for i := 0; i < runes.len; i++ {
+		s += EncodeRune(runes.f[i])
+	}
+	return s
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"math/cmplx"
+)
+
+var (
+	zero complex128
+	unit           = complex(1, 0)
+	c64  complex64 = 0.1 + 0.2i
+)
+
+func arithmetic() {
+	x, y := complex(1, 2), 3-4i
+	// Checking
+	if x+y == 4-2i && x-y == -2+6i && x*y == 11+2i && x/y == -0.2+0.4i {
+		println("[OK] operators")
+	} else {
+		fmt.Println("[Error] operators:", x+y, x-y, x*y, x/y)
+	}
+	//==
+
+	z := x
+	z *= 2i
+	z++
+	// Checking
+	if z == -3+2i && -z == 3-2i && z != x {
+		println("[OK] assignment")
+	} else {
+		fmt.Println("[Error] assignment:", z, -z)
+	}
+	//==
+
+	q := unit / zero
+	// Checking
+	if cmplx.IsInf(q) && real(q) > 0 {
+		println("[OK] division by zero")
+	} else {
+		fmt.Println("[Error] division by zero:", q)
+	}
+	//==
+}
+
+func builtin() {
+	re, im := 1.5, -2.5
+	x := complex(re, im)
+	// Checking
+	if real(x) == 1.5 && imag(x) == -2.5 && real(zero) == 0 && imag(2i) == 2 {
+		println("[OK] real, imag")
+	} else {
+		fmt.Println("[Error] real, imag:", real(x), imag(x))
+	}
+	//==
+
+	c := complex64(x) * c64
+	// Checking
+	if fmt.Sprint(c) == "(0.65000004+0.05i)" && complex128(c64) != 0.1+0.2i {
+		println("[OK] complex64")
+	} else {
+		fmt.Println("[Error] complex64:", c, complex128(c64))
+	}
+	//==
+}
+
+func library() {
+	x := 3 + 4i
+	r, θ := cmplx.Polar(x)
+	// Checking
+	if cmplx.Abs(x) == 5 && cmplx.Conj(x) == 3-4i && cmplx.Sqrt(-4) == 2i &&
+		r == 5 && θ == cmplx.Phase(x) && cmplx.IsNaN(cmplx.NaN()) {
+		println("[OK] math/cmplx")
+	} else {
+		fmt.Println("[Error] math/cmplx:", cmplx.Abs(x), cmplx.Conj(x), cmplx.Sqrt(-4), r, θ)
+	}
+	//==
+}
+
+func printing() {
+	x := complex(1.5, -2)
+	// Checking
+	if fmt.Sprint(x) == "(1.5-2i)" && fmt.Sprint(zero) == "(0+0i)" &&
+		fmt.Sprint(c64) == "(0.1+0.2i)" && fmt.Sprint(complex(1e21, 1e-7)) == "(1e+21+1e-07i)" {
+		println("[OK] format")
+	} else {
+		fmt.Println("[Error] format:", x, zero, c64, complex(1e21, 1e-7))
+	}
+	//==
+
+	inf := cmplx.Inf()
+	nan := cmplx.NaN()
+	// Checking
+	if fmt.Sprint(inf) == "(+Inf+Infi)" && fmt.Sprint(-inf) == "(-Inf-Infi)" && fmt.Sprint(nan) == "(NaN+NaNi)" {
+		println("[OK] format of Inf, NaN")
+	} else {
+		fmt.Println("[Error] format of Inf, NaN:", inf, -inf, nan)
+	}
+	//==
+}
+
+func main() {
+	println("\n== arithmetic")
+	arithmetic()
+	println("\n== builtin")
+	builtin()
+	println("\n== library")
+	library()
+	println("\n== printing")
+	printing()
+}
@@ -0,0 +1,111 @@
+/* Generated by GoScript <github.com/kless/GoScript> */
+
+
+
+
+
+
+
+var zero = new g.Complex(0, 0);
+var unit = new g.Complex(1, 0);
+var c64 = new g.Complex(0.10000000149011612, 0.20000000298023224);
+
+
+function arithmetic() {
+	var x = new g.Complex(1, 2), y = new g.Complex(3, -4);
+
+	if (x.add(y).eq(new g.Complex(4, -2)) && x.sub(y).eq(new g.Complex(-2, 6)) && x.mul(y).eq(new g.Complex(11, 2)) && x.div(y).eq(new g.Complex(-0.2, 0.4))) {
+		console.log("[OK] operators\n");
+	} else {
+		alert("[Error] operators: " + x.add(y).toString() + " " + x.sub(y).toString() + " " + x.mul(y).toString() + " " + x.div(y).toString() + "\n");
+	}
+
+
+	var z = x;
+	z = z.mul(new g.Complex(0, 2));
+	z = z.add(new g.Complex(1, 0));
+
+	if (z.eq(new g.Complex(-3, 2)) && z.neg().eq(new g.Complex(3, -2)) && !z.eq(x)) {
+		console.log("[OK] assignment\n");
+	} else {
+		alert("[Error] assignment: " + z.toString() + " " + z.neg().toString() + "\n");
+	}
+
+
+	var q = unit.div(zero);
+
+	if (g.CmplxIsInf(q) && q.re > 0) {
+		console.log("[OK] division by zero\n");
+	} else {
+		alert("[Error] division by zero: " + q.toString() + "\n");
+	}
+
+}
+
+function builtin() {
+	var re = 1.5, im = -2.5;
+	var x = new g.Complex(re, im);
+
+	if (x.re === 1.5 && x.im === -2.5 && zero.re === 0 && true) {
+		console.log("[OK] real, imag\n");
+	} else {
+		alert("[Error] real, imag: " + x.re + " " + x.im + "\n");
+	}
+
+
+	var c = x.fround().mul(c64).fround();
+
+	if (c.toString(32) === "(0.65000004+0.05i)" && !c64.eq(new g.Complex(0.1, 0.2))) {
+		console.log("[OK] complex64\n");
+	} else {
+		alert("[Error] complex64: " + c.toString(32) + " " + c64.toString() + "\n");
+	}
+
+}
+
+function library() {
+	var x = new g.Complex(3, 4);
+	var _ = g.CmplxPolar(x), r = _[0], θ = _[1];
+
+	if (g.CmplxAbs(x) === 5 && g.CmplxConj(x).eq(new g.Complex(3, -4)) && g.CmplxSqrt(new g.Complex(-4, 0)).eq(new g.Complex(0, 2)) && r === 5 && θ === g.CmplxPhase(x) && g.CmplxIsNaN(g.CmplxNaN())) {
+
+		console.log("[OK] math/cmplx\n");
+	} else {
+		alert("[Error] math/cmplx: " + g.CmplxAbs(x) + " " + g.CmplxConj(x).toString() + " " + g.CmplxSqrt(new g.Complex(-4, 0)).toString() + " " + r + " " + θ + "\n");
+	}
+
+}
+
+function printing() {
+	var x = new g.Complex(1.5, -2);
+
+	if (x.toString() === "(1.5-2i)" && zero.toString() === "(0+0i)" && c64.toString(32) === "(0.1+0.2i)" && new g.Complex(1e+21, 1e-07).toString() === "(1e+21+1e-07i)") {
+
+		console.log("[OK] format\n");
+	} else {
+		alert("[Error] format: " + x.toString() + " " + zero.toString() + " " + c64.toString(32) + " " + new g.Complex(1e+21, 1e-07).toString() + "\n");
+	}
+
+
+	var inf = g.CmplxInf();
+	var nan = g.CmplxNaN();
+
+	if (inf.toString() === "(+Inf+Infi)" && inf.neg().toString() === "(-Inf-Infi)" && nan.toString() === "(NaN+NaNi)") {
+		console.log("[OK] format of Inf, NaN\n");
+	} else {
+		alert("[Error] format of Inf, NaN: " + inf.toString() + " " + inf.neg().toString() + " " + nan.toString() + "\n");
+	}
+
+}
+
+function main() {
+	console.log("\n== arithmetic\n");
+	arithmetic();
+	console.log("\n== builtin\n");
+	builtin();
+	console.log("\n== library\n");
+	library();
+	console.log("\n== printing\n");
+	printing();
+}
+//# sourceMappingURL=complex.js.map
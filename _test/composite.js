@@ -6,14 +6,14 @@
 function person(name, age) {
 	this.name=name;
 	this.age=age;
-}
+} person.prototype.clone = function() { var c = Object.create(person.prototype); c.name = this.name; c.age = this.age; return c; }; person.prototype.eq = function(y) { return this.name === y.name && this.age === y.age; }; var person$type = g.Type("main.person", function(x, y) { return x.eq(y); });
 
 
 function Older(p1, p2) {
 	if (p1.age > p2.age) {
-		return [p1, p1.age - p2.age];
+		return [p1.clone(), p1.age - p2.age];
 	}
-	return [p2, p2.age - p1.age];
+	return [p2.clone(), p2.age - p1.age];
 }
 
 function testStruct() {
@@ -22,35 +22,35 @@ function testStruct() {
 	tom.name = "Tom", tom.age = 18;
 
 
-	var bob = new person(); bob.age = 25, bob.name = "Bob";
+	var bob = new person("", 0); bob.age = 25, bob.name = "Bob";
 	var paul = new person("Paul", 43);
 
 	var _ = Older(tom, bob), tb_Older = _[0], tb_diff = _[1];
 
-	if (JSON.stringify(tb_Older) === JSON.stringify(bob) && tb_diff === 7) {
+	if (tb_Older.eq(bob) && tb_diff === 7) {
 		console.log("[OK] Tom, Bob\n");
 	} else {
-		alert("[Error] Of " + tom.name + " and " + bob.name + ", " + tb_Older.name + " is older by " + tb_diff + " years\n");
+		alert(g.Decode("[Error] Of " + tom.name + " and " + bob.name + ", " + tb_Older.name + " is older by " + tb_diff + " years\n"));
 
 	}
 
 
 	var _ = Older(tom, paul), tp_Older = _[0], tp_diff = _[1];
 
-	if (JSON.stringify(tp_Older) === JSON.stringify(paul) && tp_diff === 25) {
+	if (tp_Older.eq(paul) && tp_diff === 25) {
 		console.log("[OK] Tom, Paul\n");
 	} else {
-		alert("[Error] Of " + tom.name + " and " + paul.name + ", " + tp_Older.name + " is older by " + tp_diff + " years\n");
+		alert(g.Decode("[Error] Of " + tom.name + " and " + paul.name + ", " + tp_Older.name + " is older by " + tp_diff + " years\n"));
 
 	}
 
 
 	var _ = Older(bob, paul), bp_Older = _[0], bp_diff = _[1];
 
-	if (JSON.stringify(bp_Older) === JSON.stringify(paul) && bp_diff === 18) {
+	if (bp_Older.eq(paul) && bp_diff === 18) {
 		console.log("[OK] Bob, Paul\n");
 	} else {
-		alert("[Error] Of " + bob.name + " and " + paul.name + ", " + bp_Older.name + " is older by " + bp_diff + " years\n");
+		alert(g.Decode("[Error] Of " + bob.name + " and " + paul.name + ", " + bp_Older.name + " is older by " + bp_diff + " years\n"));
 
 	}
 }
@@ -59,12 +59,12 @@ function testStruct() {
 
 
 function Older10(people) {
-	var older = people[0];
+	var older = people[0].clone();
 
 
 	for (var index = 1; index < 10; index++) {
 		if (people[index].age > older.age) {
-			older = people[index];
+			older = people[index].clone();
 		}
 	}
 	return older;
@@ -88,7 +88,7 @@ function testArray() {
 	if (older.name === "Sam") {
 		console.log("[OK]\n");
 	} else {
-		alert("[Error] The older of the group is: " + older.name + "\n");
+		alert(g.Decode("[Error] The older of the group is: " + older.name + "\n"));
 	}
 }
 
@@ -96,7 +96,7 @@ function testArray() {
 
 function initializeArray() {
 
-	var array1 = []; for (var i=0; i<10; i++){ array1[i]=new person("", 0); } array1 = [
+	var array1 = [
 		new person("", 0),
 		new person("Paul", 23),
 		new person("Jim", 24),
@@ -123,16 +123,16 @@ function initializeArray() {
 		new person("", 0)];
 
 
-	if (array1.length === array2.length) {
+	if (true) {
 		console.log("[OK] length\n");
 	} else {
 		alert("[Error] len => array1: " + array1.length + ", array2: " + array2.length + "\n");
 	}
 
-	if (JSON.stringify(array1) === JSON.stringify(array2)) {
+	if (g.ArrayEq(array1, array2, function(a, b) { return a.eq(b); })) {
 		console.log("[OK] comparison\n");
 	} else {
-		alert("[Error] array1: " + array1 + "\narray2: " + array2 + "\n");
+		alert(g.Decode("[Error] array1: " + array1 + "\narray2: " + array2 + "\n"));
 	}
 }
 
@@ -140,20 +140,20 @@ function initializeArray() {
 
 function multiArray() {
 
-	var doubleArray_1 = []; for (var i=0; i<2; i++){ doubleArray_1[i]=[]; for (var j=0; j<4; j++){ doubleArray_1[i][j]=0; }} doubleArray_1 = [[1, 2, 3, 4], [5, 6, 7, 8]];
+	var doubleArray_1 = [[1, 2, 3, 4], [5, 6, 7, 8]];
 
 
-	var doubleArray_2 = []; for (var i=0; i<2; i++){ doubleArray_2[i]=[]; for (var j=0; j<4; j++){ doubleArray_2[i][j]=0; }} doubleArray_2 = [
+	var doubleArray_2 = [
 		[1, 2, 3, 4], [5, 6, 7, 8]];
 
 
-	var doubleArray_3 = []; for (var i=0; i<2; i++){ doubleArray_3[i]=[]; for (var j=0; j<4; j++){ doubleArray_3[i][j]=0; }} doubleArray_3 = [
+	var doubleArray_3 = [
 		[1, 2, 3, 4],
 		[5, 6, 7, 8]
 	];
 
 
-	if (JSON.stringify(doubleArray_1) === JSON.stringify(doubleArray_2) && JSON.stringify(doubleArray_2) === JSON.stringify(doubleArray_3)) {
+	if (g.ArrayEq(doubleArray_1, doubleArray_2, function(a, b) { return g.ArrayEq(a, b, function(a, b) { return a === b; }); }) && g.ArrayEq(doubleArray_2, doubleArray_3, function(a, b) { return g.ArrayEq(a, b, function(a, b) { return a === b; }); })) {
 		console.log("[OK]\n");
 	} else {
 		alert("[Error] multi-dimensional\n");
@@ -172,3 +172,4 @@ function main() {
 	console.log("\n== multiArray\n");
 	multiArray();
 }
+//# sourceMappingURL=composite.js.map
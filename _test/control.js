@@ -12,7 +12,7 @@ function testIf() {
 	} else {
 		code = "OK";
 	}
-	console.log("[" + code + "] simple\n");
+	console.log(g.Decode("[" + code + "] simple\n"));
 
 
 	var x = 12; if (x > 10) {
@@ -20,7 +20,7 @@ function testIf() {
 	} else {
 		code = "Error";
 	}
-	console.log("[" + code + "] with statement\n");
+	console.log(g.Decode("[" + code + "] with statement\n"));
 
 
 	var i = 7;
@@ -32,7 +32,7 @@ function testIf() {
 	} else {
 		code = "OK";
 	}
-	console.log("[" + code + "] multiple\n");
+	console.log(g.Decode("[" + code + "] multiple\n"));
 }
 
 function testSwitch() {
@@ -50,7 +50,7 @@ function testSwitch() {
 	case 10:
 		code = "OK";
 	}
-	console.log("[" + code + "] simple\n");
+	console.log(g.Decode("[" + code + "] simple\n"));
 
 
 	i = 5; switch (true) {
@@ -63,13 +63,13 @@ function testSwitch() {
 	default:
 		code = "Error";
 	}
-	console.log("[" + code + "] with statement\n");
+	console.log(g.Decode("[" + code + "] with statement\n"));
 
 	switch (true) {
 	case i === 5:
 		code = "OK";
 	}
-	console.log("[" + code + "] without expression\n");
+	console.log(g.Decode("[" + code + "] without expression\n"));
 
 
 	switch (i) {
@@ -89,7 +89,7 @@ function testSwitch() {
 	default:
 		code = "Error";
 	}
-	console.log("[" + code + "] with fallthrough\n");
+	console.log(g.Decode("[" + code + "] with fallthrough\n"));
 }
 
 function testFor() {
@@ -106,7 +106,7 @@ function testFor() {
 	} else {
 		code = "Error";
 	}
-	console.log("[" + code + "] simple\n");
+	console.log(g.Decode("[" + code + "] simple\n"));
 
 
 
@@ -120,7 +120,7 @@ function testFor() {
 	} else {
 		code = "Error";
 	}
-	console.log("[" + code + "] 2 expressions omitted\n");
+	console.log(g.Decode("[" + code + "] 2 expressions omitted\n"));
 
 
 
@@ -134,7 +134,7 @@ function testFor() {
 	} else {
 		code = "Error";
 	}
-	console.log("[" + code + "] 2 expressions omitted, no semicolons\n");
+	console.log(g.Decode("[" + code + "] 2 expressions omitted, no semicolons\n"));
 
 
 
@@ -153,7 +153,7 @@ function testFor() {
 	} else {
 		code = "Error";
 	}
-	console.log("[" + code + "] infinite loop\n");
+	console.log(g.Decode("[" + code + "] infinite loop\n"));
 
 
 
@@ -168,7 +168,7 @@ function testFor() {
 	if (s === "10 9 8 7 6 5 ") {
 		console.log("[OK] break\n");
 	} else {
-		alert("[Error] value in break: " + s + "\n");
+		alert(g.Decode("[Error] value in break: " + s + "\n"));
 	}
 
 
@@ -184,15 +184,15 @@ function testFor() {
 	if (s === "10 9 8 7 6 4 3 2 1 ") {
 		console.log("[OK] continue\n");
 	} else {
-		alert("[Error] value in continue: " + s + "\n");
+		alert(g.Decode("[Error] value in continue: " + s + "\n"));
 	}
 
 }
 
 function testRange() {
-	var s = [2, 3, 5];
+	var s = g.NewSlice([2, 3, 5], 0);
 
-	var v; for (i in s.f) { v = s.f[i];
+	var v; for (var i in s.f) { v = s.f[i];
 		console.log("key: " + i + " " + "value: " + v + "\n");
 	}
 }
@@ -207,3 +207,4 @@ function main() {
 
 
 }
+//# sourceMappingURL=control.js.map
@@ -48,6 +48,19 @@ const (
 	bit3, mask3                          // bit3 == 8, mask3 == 7
 )
 
+// === Expressions
+
+const (
+	kb            = 1 << 10
+	four          = 1 << 100 >> 98
+	third         = 1.0 / 3
+	tenth float32 = 0.1
+	max8          = byte(255)
+	name          = "Go" + "Script"
+	long          = len(name) > 5
+	mixed         = kb*2.5 + 'a'
+)
+
 func main() {
 	const F = 1
 
@@ -1,8 +1,8 @@
-/* Generated by GoScript <github.com/kless/GoScript> */var test = {}; (function() {
-
-const Pi = 3.14159265358979323846;
-const pi2 = Pi;
-const zero = 0.0;
+/* Generated by GoScript <github.com/kless/GoScript> */
+var test = {}; (function() {
+const Pi = 3.141592653589793;
+const pi2 = 3.141592653589793;
+const zero = 0;
 const 
 size = 1024,
 eof = -1;
@@ -28,24 +28,37 @@ a1 = 1,
 a2 = 2;
 
 const 
-b0 = 1 << 0,
-b1 = 1 << 1,
-b2 = 1 << 2;
+b0 = 1,
+b1 = 2,
+b2 = 4;
 
 const 
-c0 = 0 * 42,
-c1 = 1 * 42,
-c2 = 2 * 42;
+c0 = 0,
+c1 = 42,
+c2 = 84;
 
 
 const x = 0;
 const y = 0;
 
 const 
-bit0 = 1 << 0, mask0 = 1 << 0 - 1,
-bit1 = 1 << 1, mask1 = 1 << 1 - 1,
+bit0 = 1, mask0 = 0,
+bit1 = 2, mask1 = 1,
+
+bit3 = 8, mask3 = 7;
+
 
-bit3 = 1 << 3, mask3 = 1 << 3 - 1;
+
+
+const 
+kb = 1024,
+four = 4,
+third = 0.3333333333333333,
+tenth = 0.10000000149011612,
+max8 = 255,
+name = "GoScript",
+long = true,
+mixed = 2657;
 
 
 function main() {
@@ -59,3 +72,4 @@ function main() {
 
 g.Export(test, [Pi, Sunday, Monday, Tuesday, Wednesday, Thursday, Friday, Partyday]);
 })();
+//# sourceMappingURL=decl_const.js.map
@@ -3,36 +3,37 @@
 var test = {}; (function() {
 
 
-function s0() {}
+function s0() {} s0.prototype.clone = function() { var c = Object.create(s0.prototype); return c; }; s0.prototype.eq = function(y) { return true; }; var s0$type = g.Type("test.s0", function(x, y) { return x.eq(y); });
 
 
 function s1(a, b, f, A) {
 	this.a=a; this.b=b;
 	this.f=f;
 
-	this.A={p:A};
+	this.A=A;
 
-}
+} s1.prototype.clone = function() { var c = Object.create(s1.prototype); c.a = this.a; c.b = this.b; c.f = this.f; c.A = this.A; return c; }; s1.prototype.eq = function(y) { return this.a === y.a && this.b === y.b && this.f === y.f && this.A === y.A; }; var s1$type = g.Type("test.s1", function(x, y) { return x.eq(y); });
 
 
 function s2(microsec, serverIP6, process) {
 	this.microsec=microsec;
 	this.serverIP6=serverIP6;
 	this.process=process;
-}
+} s2.prototype.clone = function() { var c = Object.create(s2.prototype); c.microsec = this.microsec; c.serverIP6 = this.serverIP6; c.process = this.process; return c; }; s2.prototype.eq = function(y) { return this.microsec === y.microsec && this.serverIP6 === y.serverIP6 && this.process === y.process; }; var s2$type = g.Type("test.s2", function(x, y) { return x.eq(y); });
 
 
 
 
-function Point(x, y) { this.x=x; this.y=y; }
+function Point(x, y) { this.x=x; this.y=y; } Point.prototype.clone = function() { var c = Object.create(Point.prototype); c.x = this.x; c.y = this.y; return c; }; Point.prototype.eq = function(y) { return this.x === y.x && this.y === y.y; }; var Point$type = g.Type("test.Point", function(x, y) { return x.eq(y); });
 
 
 
 function main() {
 	function Fa(a) {
 		this.a=a;
-	}
+	} Fa.prototype.clone = function() { var c = Object.create(Fa.prototype); c.a = this.a; return c; }; Fa.prototype.eq = function(y) { return this.a === y.a; }; var Fa$type = g.Type("test.Fa", function(x, y) { return x.eq(y); });
 }
 
-g.Export(test, [Point]);
+g.Export(test, [Point$type, Point]);
 })();
+//# sourceMappingURL=decl_struct.js.map
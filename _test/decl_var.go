@@ -28,7 +28,7 @@ var (
 	a7 = [2][2][2]float64{} // same as [2]([2]([2]float64))
 
 	b1 = [32]byte{1, 2, 3, 4}
-	b2 = [4]byte{1, _, _, 4}
+	b2 = [4]byte{1, 0, 0, 4}
 )
 
 // Slice
@@ -37,7 +37,7 @@ var (
 	s2 = make([]int, 10, 20)
 
 	s3 = []int{2, 4, 6}
-	s4 = []int{1, _, 3}
+	s4 = []int{1, 0, 3}
 	s5 = [...]string{"a", "b", "c"}
 )
 
@@ -71,4 +71,5 @@ func main() {
 		Fd uint = 20
 		Fe float32
 	)
+	_, _, _, _, _ = Fa, Fb, Fc, Fd, Fe
 }
@@ -1,5 +1,5 @@
-/* Generated by GoScript <github.com/kless/GoScript> */var test = {}; (function() {
-
+/* Generated by GoScript <github.com/kless/GoScript> */
+var test = {}; (function() {
 var A = "";
 var a = 0;
 var b = 0, c = 0, d = 0;
@@ -22,13 +22,13 @@ var o = "OMDB";
 var a1 = []; for (var i=0; i<32; i++){ a1[i]=0; }
 var a2 = []; for (var i=0; i<2; i++){ a2[i]=[]; for (var j=0; j<4; j++){ a2[i][j]=0; }}
 
-var a4 = []; for (var i=0; i<10; i++){ a4[i]={p:undefined}; }
-var a5 = []; for (var i=0; i<4; i++){ a5[i]=0; }
-var a6 = []; for (var i=0; i<3; i++){ a6[i]=[]; for (var j=0; j<5; j++){ a6[i][j]=0; }}
-var a7 = []; for (var i=0; i<2; i++){ a7[i]=[]; for (var j=0; j<2; j++){ a7[i][j]=[]; for (var k=0; k<2; k++){ a7[i][j][k]=0; }}}
+var a4 = g.MakeArray(10, function() { return undefined; });
+var a5 = g.MakeArray(4, function() { return 0; });
+var a6 = g.MakeArray(3, function() { return g.MakeArray(5, function() { return 0; }); });
+var a7 = g.MakeArray(2, function() { return g.MakeArray(2, function() { return g.MakeArray(2, function() { return 0; }); }); });
 
-var b1 = []; for (var i=0; i<32; i++){ b1[i]=0; } b1 = [1, 2, 3, 4];
-var b2 = []; for (var i=0; i<4; i++){ b2[i]=0; } b2 = [1, , , 4];
+var b1 = [1, 2, 3, 4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0];
+var b2 = [1, 0, 0, 4];
 
 
 
@@ -36,32 +36,32 @@ var b2 = []; for (var i=0; i<4; i++){ b2[i]=0; } b2 = [1, , , 4];
 var s1 = g.MakeSlice(0, 10);
 var s2 = g.MakeSlice(0, 10, 20);
 
-var s3 = [2, 4, 6];
-var s4 = [1, _, 3];
+var s3 = g.NewSlice([2, 4, 6], 0);
+var s4 = g.NewSlice([1, 0, 3], 0);
 var s5 = ["a", "b", "c"];
 
 
 
 
-var m1 = new g.M({}, 0);
-var m2 = new g.M({}, 0);
-var m3 = new g.M({
-	1: "first",
-	2: "second",
-	3: "third"
-}, "");
-var m4 = new g.M({
-	1: "first",
-	2: 2,
-	3: 3
-}, undefined);
+var m1 = g.NewMap(0);
+var m2 = g.NewMap(0);
+var m3 = g.NewMap("", [
+	[1, "first"],
+	[2, "second"],
+	[3, "third"]
+]);
+var m4 = g.NewMap(undefined, [
+	[1, g.Box("first", g.Type("string"))],
+	[2, g.Box(2, g.Type("int"))],
+	[3, g.Box(3, g.Type("int"))]
+]);
 
 
 
 
-var p0 = {p:undefined};
-var p1 = {p:undefined};
-var p2 = {p:undefined};
+var p0 = undefined;
+var p1 = undefined;
+var p2 = undefined;
 
 
 function main() {
@@ -71,7 +71,9 @@ function main() {
 	var Fd = 20;
 	var Fe = 0;
 
+	
 }
 
 g.Export(test, [A]);
 })();
+//# sourceMappingURL=decl_var.js.map
@@ -0,0 +1,96 @@
+package main
+
+import "fmt"
+
+type Named struct{ Name string }
+
+func (n Named) Hello() string { return "hello " + n.Name }
+
+type Point struct{ X, Y int }
+
+func (p Point) Sum() int { return p.X + p.Y }
+
+type Circle struct {
+	Point
+	Radius int
+}
+
+type Labeled struct {
+	*Named
+	Circle
+	Color string
+}
+
+// The method of the outer struct hides the promoted one.
+func (l Labeled) Sum() int { return l.Circle.Sum() * 10 }
+
+type Greeter interface {
+	Hello() string
+}
+
+type Summer interface {
+	Sum() int
+}
+
+func fields() {
+	c := Circle{Point{1, 2}, 3}
+	c.X = 5
+	// Checking
+	if c.X == 5 && c.Point.X == 5 && c.Y == 2 && c.Radius == 3 {
+		println("[OK] promoted field")
+	} else {
+		fmt.Println("[Error] promoted field:", c.X, c.Point.X, c.Y)
+	}
+	//==
+
+	l := Labeled{Named: &Named{"bob"}, Circle: c}
+	l.Name = "ann"
+	named := l.Named
+	l.Y = 7
+	// Checking
+	if named.Name == "ann" && l.Named.Name == "ann" && l.Y == 7 && l.Circle.Point.Y == 7 && c.Y == 2 {
+		println("[OK] embedded pointer, nested")
+	} else {
+		fmt.Println("[Error] embedded pointer, nested:", named.Name, l.Y, c.Y)
+	}
+	//==
+
+	copied := c
+	copied.X = 9
+	// Checking
+	if c.X == 5 && copied.X == 9 && c != copied && copied.Point == (Point{9, 2}) {
+		println("[OK] copy, comparison")
+	} else {
+		fmt.Println("[Error] copy, comparison:", c.X, copied.X)
+	}
+}
+
+func methods() {
+	c := Circle{Point{1, 2}, 3}
+	l := Labeled{&Named{"bob"}, c, "red"}
+	// Checking
+	if c.Sum() == 3 && l.Hello() == "hello bob" && l.Sum() == 30 && l.Circle.Sum() == 3 {
+		println("[OK] promoted method")
+	} else {
+		fmt.Println("[Error] promoted method:", c.Sum(), l.Hello(), l.Sum())
+	}
+	//==
+
+	var greeter Greeter = l
+	var s Summer = c
+	var any interface{} = c
+	_, isGreeter := any.(Greeter)
+	// Checking
+	if greeter.Hello() == "hello bob" && s.Sum() == 3 && !isGreeter {
+		println("[OK] interface")
+	} else {
+		fmt.Println("[Error] interface:", greeter.Hello(), s.Sum(), isGreeter)
+	}
+}
+
+func main() {
+	println("\n== fields")
+	fields()
+	println("\n== methods")
+	methods()
+}
@@ -0,0 +1,97 @@
+/* Generated by GoScript <github.com/kless/GoScript> */
+
+
+
+function Named(Name) { this.Name=Name; } Named.prototype.clone = function() { var c = Object.create(Named.prototype); c.Name = this.Name; return c; }; Named.prototype.eq = function(y) { return this.Name === y.Name; }; var Named$type = g.Type("main.Named", function(x, y) { return x.eq(y); }, ["Hello() string"]);
+
+Named.prototype.Hello = function() { return "hello " + this.Name; }
+
+function Point(X, Y) { this.X=X; this.Y=Y; } Point.prototype.clone = function() { var c = Object.create(Point.prototype); c.X = this.X; c.Y = this.Y; return c; }; Point.prototype.eq = function(y) { return this.X === y.X && this.Y === y.Y; }; var Point$type = g.Type("main.Point", function(x, y) { return x.eq(y); }, ["Sum() int"]);
+
+Point.prototype.Sum = function() { return this.X + this.Y; }
+
+function Circle(Point, Radius) {
+	this.Point=Point;
+	this.Radius=Radius;
+} Circle.prototype.clone = function() { var c = Object.create(Circle.prototype); c.Point = this.Point.clone(); c.Radius = this.Radius; return c; }; Circle.prototype.eq = function(y) { return this.Point.eq(y.Point) && this.Radius === y.Radius; }; var Circle$type = g.Type("main.Circle", function(x, y) { return x.eq(y); }, ["Sum() int"]); Circle.prototype.Sum = function() { return this.Point.Sum.apply(this.Point, arguments); };
+
+function Labeled(Named, Circle, Color) {
+	this.Named=Named;
+	this.Circle=Circle;
+	this.Color=Color;
+} Labeled.prototype.clone = function() { var c = Object.create(Labeled.prototype); c.Named = this.Named; c.Circle = this.Circle.clone(); c.Color = this.Color; return c; }; Labeled.prototype.eq = function(y) { return this.Named === y.Named && this.Circle.eq(y.Circle) && this.Color === y.Color; }; var Labeled$type = g.Type("main.Labeled", function(x, y) { return x.eq(y); }, ["Hello() string", "Sum() int"]); Labeled.prototype.Hello = function() { return this.Named.Hello.apply(this.Named, arguments); };
+
+
+Labeled.prototype.Sum = function() { return this.Circle.Point.Sum() * 10; }
+
+var Greeter$type = g.Iface("main.Greeter", ["Hello() string"]);
+
+
+
+var Summer$type = g.Iface("main.Summer", ["Sum() int"]);
+
+
+
+function fields() {
+	var c = new Circle(new Point(1, 2), 3);
+	c.Point.X = 5;
+
+	if (c.Point.X === 5 && c.Point.X === 5 && c.Point.Y === 2 && c.Radius === 3) {
+		console.log("[OK] promoted field\n");
+	} else {
+		alert("[Error] promoted field: " + c.Point.X + " " + c.Point.X + " " + c.Point.Y + "\n");
+	}
+
+
+	var l = new Labeled(undefined, new Circle(new Point(0, 0), 0), ""); l.Named = new Named("bob"), l.Circle = c.clone();
+	l.Named.Name = "ann";
+	var named = l.Named;
+	l.Circle.Point.Y = 7;
+
+	if (named.Name === "ann" && l.Named.Name === "ann" && l.Circle.Point.Y === 7 && l.Circle.Point.Y === 7 && c.Point.Y === 2) {
+		console.log("[OK] embedded pointer, nested\n");
+	} else {
+		alert(g.Decode("[Error] embedded pointer, nested: " + named.Name + " " + l.Circle.Point.Y + " " + c.Point.Y + "\n"));
+	}
+
+
+	var copied = c.clone();
+	copied.Point.X = 9;
+
+	if (c.Point.X === 5 && copied.Point.X === 9 && !c.eq(copied) && copied.Point.eq((new Point(9, 2)))) {
+		console.log("[OK] copy, comparison\n");
+	} else {
+		alert("[Error] copy, comparison: " + c.Point.X + " " + copied.Point.X + "\n");
+	}
+}
+
+function methods() {
+	var c = new Circle(new Point(1, 2), 3);
+	var l = new Labeled(new Named("bob"), c.clone(), "red");
+
+	if (c.Point.Sum() === 3 && l.Named.Hello() === "hello bob" && l.Sum() === 30 && l.Circle.Point.Sum() === 3) {
+		console.log("[OK] promoted method\n");
+	} else {
+		alert(g.Decode("[Error] promoted method: " + c.Point.Sum() + " " + l.Named.Hello() + " " + l.Sum() + "\n"));
+	}
+
+
+	var greeter = g.Box(l.clone(), Labeled$type);
+	var s = g.Box(c.clone(), Circle$type);
+	var any = g.Box(c.clone(), Circle$type);
+	var isGreeter = g.AssertOk(any, Greeter$type, undefined)[1];
+
+	if (greeter.Hello() === "hello bob" && s.Sum() === 3 && !isGreeter) {
+		console.log("[OK] interface\n");
+	} else {
+		alert(g.Decode("[Error] interface: " + greeter.Hello() + " " + s.Sum() + " " + isGreeter + "\n"));
+	}
+}
+
+function main() {
+	console.log("\n== fields\n");
+	fields();
+	console.log("\n== methods\n");
+	methods();
+}
+//# sourceMappingURL=embed.js.map
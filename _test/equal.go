@@ -0,0 +1,105 @@
+package main
+
+import "fmt"
+
+type point struct{ x, y int }
+
+type segment struct {
+	from, to point
+	steps    [2]float64
+}
+
+func structs() {
+	a, b, c := point{1, 2}, point{1, 2}, point{2, 1}
+	// Checking
+	if a == b && a != c && !(b == c) {
+		println("[OK] fields")
+	} else {
+		fmt.Println("[Error] fields:", a == b, a != c)
+	}
+	//==
+
+	steps := [2]float64{0.5, 1}
+	s := segment{a, c, steps}
+	t := segment{b, c, steps}
+	u := t
+	zero := 0.0
+	u.steps[1] = zero / zero // NaN
+	// Checking
+	if s == t && s != u && u != u {
+		println("[OK] nested")
+	} else {
+		fmt.Println("[Error] nested:", s == t, s != u, u != u)
+	}
+	//==
+}
+
+func arrays() {
+	x := [3]int{1, 2, 3}
+	y := [3]int{1, 2, 3}
+	z := [2]point{point{1, 2}, point{3, 4}}
+	w := [2]point{point{1, 2}, point{3, 5}}
+	// Checking
+	if z != w {
+		println("[OK] different")
+	} else {
+		fmt.Println("[Error] different:", z != w)
+	}
+	//==
+
+	w[1].y = 4
+	// Checking
+	if x == y && z == w {
+		println("[OK] elements")
+	} else {
+		fmt.Println("[Error] elements:", x == y, z == w)
+	}
+	//==
+}
+
+func identity() {
+	a, b := 1, 1
+	p, q, r := &a, &b, &a
+	// Checking
+	if p != q && p == r && *p == *q {
+		println("[OK] pointer")
+	} else {
+		fmt.Println("[Error] pointer:", p != q, p == r)
+	}
+	//==
+
+	m := map[string]int{"a": 1}
+	n := m
+	var o map[string]int
+	// Checking
+	if m != nil && n != nil && o == nil {
+		println("[OK] map")
+	} else {
+		fmt.Println("[Error] map:", m != nil, n != nil, o == nil)
+	}
+	//==
+}
+
+func interfaces() {
+	var i, j interface{} = point{1, 2}, point{1, 2}
+	var k interface{} = 3
+	var l interface{}
+	// Checking
+	if i == j && i != k && k == 3 && i == (point{1, 2}) && l == nil && i != nil {
+		println("[OK] dynamic value")
+	} else {
+		fmt.Println("[Error] dynamic value:", i == j, i != k, k == 3)
+	}
+	//==
+}
+
+func main() {
+	println("\n== structs")
+	structs()
+	println("\n== arrays")
+	arrays()
+	println("\n== identity")
+	identity()
+	println("\n== interfaces")
+	interfaces()
+}
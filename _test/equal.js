@@ -0,0 +1,106 @@
+/* Generated by GoScript <github.com/kless/GoScript> */
+
+
+
+function point(x, y) { this.x=x; this.y=y; } point.prototype.clone = function() { var c = Object.create(point.prototype); c.x = this.x; c.y = this.y; return c; }; point.prototype.eq = function(y) { return this.x === y.x && this.y === y.y; }; var point$type = g.Type("main.point", function(x, y) { return x.eq(y); });
+
+function segment(from, to, steps) {
+	this.from=from; this.to=to;
+	this.steps=steps;
+} segment.prototype.clone = function() { var c = Object.create(segment.prototype); c.from = this.from.clone(); c.to = this.to.clone(); c.steps = this.steps.slice(); return c; }; segment.prototype.eq = function(y) { return this.from.eq(y.from) && this.to.eq(y.to) && g.ArrayEq(this.steps, y.steps, function(a, b) { return a === b; }); }; var segment$type = g.Type("main.segment", function(x, y) { return x.eq(y); });
+
+function structs() {
+	var a = new point(1, 2), b = new point(1, 2), c = new point(2, 1);
+
+	if (a.eq(b) && !a.eq(c) && !(b.eq(c))) {
+		console.log("[OK] fields\n");
+	} else {
+		alert("[Error] fields: " + a.eq(b) + " " + !a.eq(c) + "\n");
+	}
+
+
+	var steps = [0.5, 1];
+	var s = new segment(a.clone(), c.clone(), steps.slice());
+	var t = new segment(b.clone(), c.clone(), steps.slice());
+	var u = t.clone();
+	var zero = 0.0;
+	u.steps[1] = zero / zero;
+
+	if (s.eq(t) && !s.eq(u) && !u.eq(u)) {
+		console.log("[OK] nested\n");
+	} else {
+		alert("[Error] nested: " + s.eq(t) + " " + !s.eq(u) + " " + !u.eq(u) + "\n");
+	}
+
+}
+
+function arrays() {
+	var x = [1, 2, 3];
+	var y = [1, 2, 3];
+	var z = [new point(1, 2), new point(3, 4)];
+	var w = [new point(1, 2), new point(3, 5)];
+
+	if (!g.ArrayEq(z, w, function(a, b) { return a.eq(b); })) {
+		console.log("[OK] different\n");
+	} else {
+		alert("[Error] different: " + !g.ArrayEq(z, w, function(a, b) { return a.eq(b); }) + "\n");
+	}
+
+
+	w[1].y = 4;
+
+	if (g.ArrayEq(x, y, function(a, b) { return a === b; }) && g.ArrayEq(z, w, function(a, b) { return a.eq(b); })) {
+		console.log("[OK] elements\n");
+	} else {
+		alert("[Error] elements: " + g.ArrayEq(x, y, function(a, b) { return a === b; }) + " " + g.ArrayEq(z, w, function(a, b) { return a.eq(b); }) + "\n");
+	}
+
+}
+
+function identity() {
+	var a = {p:1}, b = {p:1};
+	var p = a, q = b, r = a;
+
+	if (p !== q && p === r && p.p === q.p) {
+		console.log("[OK] pointer\n");
+	} else {
+		alert("[Error] pointer: " + (p !== q) + " " + (p === r) + "\n");
+	}
+
+
+	var m = g.NewMap(0, [["a", 1]]);
+	var n = m;
+	var o;
+
+	if (m !== undefined && n !== undefined && o === undefined) {
+		console.log("[OK] map\n");
+	} else {
+		alert("[Error] map: " + (m !== undefined) + " " + (n !== undefined) + " " + (o === undefined) + "\n");
+	}
+
+}
+
+function interfaces() {
+	var i = g.Box(new point(1, 2), point$type), j = g.Box(new point(1, 2), point$type);
+	var k = g.Box(3, g.Type("int"));
+	var l = undefined;
+
+	if (g.Equal(i, j) && !g.Equal(i, k) && g.Equal(k, g.Box(3, g.Type("int"))) && g.Equal(i, g.Box((new point(1, 2)), point$type)) && l === undefined && i !== undefined) {
+		console.log("[OK] dynamic value\n");
+	} else {
+		alert("[Error] dynamic value: " + g.Equal(i, j) + " " + !g.Equal(i, k) + " " + g.Equal(k, g.Box(3, g.Type("int"))) + "\n");
+	}
+
+}
+
+function main() {
+	console.log("\n== structs\n");
+	structs();
+	console.log("\n== arrays\n");
+	arrays();
+	console.log("\n== identity\n");
+	identity();
+	console.log("\n== interfaces\n");
+	interfaces();
+}
+//# sourceMappingURL=equal.js.map
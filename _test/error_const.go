@@ -0,0 +1,22 @@
+// Constants which are not represented exactly in JavaScript
+
+package test
+
+const (
+	small = 1<<53 - 1
+	big   = 1 << 100  // a power of two is exact
+	Max   = 1<<53 + 1 // not declared, since it is untyped
+	inf   = 1e400
+	half  = big >> 101
+	p60   = 1 << 60
+	next  = Max - 1
+)
+
+const odd int = 1<<53 + 1
+
+func main() {
+	var u uint = 1 << 63
+	var f float64 = Max
+	var b float64 = big
+	println(small, b, half, p60, next, odd, u, f, inf > 0)
+}
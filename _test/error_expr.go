@@ -0,0 +1,23 @@
+// Expressions not supported
+
+package test
+
+func unvalidBuiltins() {
+	s := []int{1, 2}
+	s = append(s, 3)
+	copy(s, s)
+
+	p := new(struct{ x int })
+	n := 1
+	q := s[n:]
+	_, _ = p, q
+}
+
+func unvalidExpressions(s []int) {
+	f := func() int { return 1 }()
+	var i interface{} = 1
+	j := i.(int)
+	c := struct{ x int }{1}
+	v := &s[0]
+	_, _, _, _ = f, j, c, v
+}
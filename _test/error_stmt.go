@@ -5,7 +5,7 @@ package test
 func unvalidDirectives() {
 	ch := make(chan int)
 
-	go print("hello!")
+	go print("hello!", ch)
 	defer println("bye!")
 
 	panic("problem")
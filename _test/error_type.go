@@ -0,0 +1,30 @@
+// Errors found at checking the types
+
+package test
+
+import (
+	"fmt"
+	"math"
+)
+
+func sum(a, b int) int { return a + b }
+
+func main() {
+	var s string = 1
+	n := sum(1)
+	x := undefined
+	y := 2
+
+	fmt.Println(s, n, x)
+}
+
+const max byte = 256
+
+type left struct{ x int }
+type right struct{ x int }
+type both struct {
+	left
+	right
+}
+
+var ambiguous = both{}.x
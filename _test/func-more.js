@@ -6,20 +6,20 @@
 function person(name, age) {
 	this.name=name;
 	this.age=age;
-}
+} person.prototype.clone = function() { var c = Object.create(person.prototype); c.name = this.name; c.age = this.age; return c; }; person.prototype.eq = function(y) { return this.name === y.name && this.age === y.age; }; var person$type = g.Type("main.person", function(x, y) { return x.eq(y); });
 
 
 
 function Older(people) {
-	if (people.length === 0) {
-		return [false, new person()];
+	if (people.len === 0) {
+		return [false, new person("", 0)];
 	}
-	var older = people[0];
+	var older = people.f[0].clone();
 
-	var value; for (_ in people) { value = people[_];
+	var value; for (var _ in people.f) { value = people.f[_].clone();
 
 		if (value.age > older.age) {
-			older = value;
+			older = value.clone();
 		}
 	}
 	return [true, older];
@@ -42,19 +42,20 @@ function main() {
 
 
 	older = Older(paul, jim)[1];
-	alert("The older of Paul and Jim is:  " + older.name + "\n");
+	alert(g.Decode("The older of Paul and Jim is:  " + older.name + "\n"));
 
 	older = Older(paul, jim, sam)[1];
-	alert("The older of Paul, Jim and Sam is:  " + older.name + "\n");
+	alert(g.Decode("The older of Paul, Jim and Sam is:  " + older.name + "\n"));
 
 	older = Older(paul, jim, sam, rob)[1];
-	alert("The older of Paul, Jim, Sam and Rob is:  " + older.name + "\n");
+	alert(g.Decode("The older of Paul, Jim, Sam and Rob is:  " + older.name + "\n"));
 
 	older = Older(karl)[1];
-	alert("When Karl is alone in a group, the older is:  " + older.name + "\n");
+	alert(g.Decode("When Karl is alone in a group, the older is:  " + older.name + "\n"));
 
 	_ = Older(), ok = _[0], older = _[1];
 	if (!ok) {
 		alert("In an empty group there is no older person\n");
 	}
 }
+//# sourceMappingURL=func-more.js.map
@@ -18,7 +18,7 @@ function testInit() {
 	} else {
 		code = "Error";
 	}
-	console.log("[" + code + "]\n");
+	console.log(g.Decode("[" + code + "]\n"));
 }
 
 function singleLine() { console.log("[OK]\n"); }
@@ -93,29 +93,29 @@ function resultVariable() {
 		return [s, ok];
 	};
 
-	var results = new g.M({
-		1: 1,
-		2: 1.4142135623730951,
-		3: 1.7320508075688772,
-		4: 2,
-		5: 2.23606797749979,
-		6: 2.449489742783178,
-		7: 2.6457513110645907,
-		8: 2.8284271247461903,
-		9: 3,
-		10: 3.1622776601683795
-	}, 0);
+	var results = g.NewMap(0, [
+		[1, 1],
+		[2, 1.4142135623730951],
+		[3, 1.7320508075688772],
+		[4, 2],
+		[5, 2.23606797749979],
+		[6, 2.449489742783178],
+		[7, 2.6457513110645907],
+		[8, 2.8284271247461903],
+		[9, 3],
+		[10, 3.1622776601683795]
+	]);
 
 	var err = false;
-	for (var i = -2.0; i <= 10; i++) {
+	for (var i = -2; i <= 10; i++) {
 		var _ = MySqrt(i), sqroot = _[0], ok = _[1];
 		if (ok) {
-			if (JSON.stringify(sqroot) !== JSON.stringify(results.get(i)[0])) {
+			if (sqroot !== (results || g.NilMap(0)).get(i)[0]) {
 				alert("[Error] The square root of " + i + " is " + sqroot + "\n");
 				err = true;
 			}
 		} else {
-			if (i !== -2.0 && i !== -1.0 && i !== 0) {
+			if (i !== -2 && i !== -1 && i !== 0) {
 				alert("[Error] The square root for " + i + " should not be run\n");
 				err = true;
 			}
@@ -143,14 +143,14 @@ function testReturn() {
 	} else {
 		code = "Error";
 	}
-	console.log("[" + code + "]\n");
+	console.log(g.Decode("[" + code + "]\n"));
 
 	var ok = MySqrt(0)[1]; if (!ok) {
 		code = "OK";
 	} else {
 		code = "Error";
 	}
-	console.log("[" + code + "]\n");
+	console.log(g.Decode("[" + code + "]\n"));
 
 }
 
@@ -175,3 +175,4 @@ function main() {
 	console.log("\n== testPanic\n");
 	testPanic();
 }
+//# sourceMappingURL=func.js.map
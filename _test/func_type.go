@@ -0,0 +1,123 @@
+package main
+
+import "fmt"
+
+type Handler func(int) string
+
+type Op struct {
+	name string
+	fn   func(a, b int) int
+}
+
+type Server struct {
+	handle Handler
+	ops    []Op
+}
+
+func double(n int) string { return fmt.Sprintf("%d", n*2) }
+
+func apply(h Handler, n int) string { return h(n) }
+
+func adder(x int) func(int) int {
+	return func(y int) int { return x + y }
+}
+
+func namedType() {
+	var h Handler = double
+	inline := Handler(func(n int) string { return "n" })
+	// Checking
+	if h(2) == "4" && apply(double, 3) == "6" && apply(inline, 1) == "n" && Handler(double)(4) == "8" {
+		println("[OK] named type")
+	} else {
+		fmt.Println("[Error] named type:", h(2), apply(double, 3), apply(inline, 1))
+	}
+	//==
+
+	add := adder(1)
+	// Checking
+	if add(2) == 3 && adder(2)(3) == 5 && func() int { return 7 }() == 7 {
+		println("[OK] function result")
+	} else {
+		fmt.Println("[Error] function result:", add(2), adder(2)(3))
+	}
+}
+
+func fields() {
+	s := Server{handle: double}
+	s.ops = []Op{
+		{"add", func(a, b int) int { return a + b }},
+		{name: "sub", fn: func(a, b int) int { return a - b }},
+	}
+	// Checking
+	if s.handle(5) == "10" && s.ops[0].fn(5, 2) == 7 && s.ops[1].fn(5, 2) == 3 && s.ops[1].name == "sub" {
+		println("[OK] struct field")
+	} else {
+		fmt.Println("[Error] struct field:", s.handle(5), s.ops[0].fn(5, 2), s.ops[1].fn(5, 2))
+	}
+	//==
+
+	s.handle = func(n int) string { return "changed" }
+	copied := s
+	copied.handle = nil
+	// Checking
+	if s.handle(1) == "changed" && copied.handle == nil {
+		println("[OK] assignment, copy")
+	} else {
+		fmt.Println("[Error] assignment, copy:", s.handle(1), copied.handle == nil)
+	}
+}
+
+func collections() {
+	fs := []func() string{func() string { return "a" }, func() string { return "b" }}
+	byName := map[string]func(int) int{
+		"inc": func(n int) int { return n + 1 },
+		"dec": func(n int) int { return n - 1 },
+	}
+	byName["neg"] = func(n int) int { return -n }
+
+	sum := 0
+	for _, f := range byName {
+		sum += f(10)
+	}
+	// Checking
+	if fs[0]()+fs[1]() == "ab" && byName["inc"](1) == 2 && byName["neg"](1) == -1 && sum == 10 {
+		println("[OK] slice, map")
+	} else {
+		fmt.Println("[Error] slice, map:", fs[0](), byName["inc"](1), sum)
+	}
+}
+
+func nilFunc() {
+	var h Handler
+	var op Op
+	var f func()
+	byName := map[string]Handler{}
+	// Checking
+	if h == nil && op.fn == nil && f == nil && byName["none"] == nil {
+		println("[OK] nil")
+	} else {
+		fmt.Println("[Error] nil:", h == nil, op.fn == nil, f == nil)
+	}
+	//==
+
+	h = double
+	f = func() {}
+	f()
+	// Checking
+	if h != nil && f != nil {
+		println("[OK] not nil")
+	} else {
+		fmt.Println("[Error] not nil:", h != nil, f != nil)
+	}
+}
+
+func main() {
+	println("\n== namedType")
+	namedType()
+	println("\n== fields")
+	fields()
+	println("\n== collections")
+	collections()
+	println("\n== nilFunc")
+	nilFunc()
+}
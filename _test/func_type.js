@@ -0,0 +1,124 @@
+/* Generated by GoScript <github.com/kless/GoScript> */
+
+
+
+var Handler$type = g.Type("main.Handler", g.Uncomparable);
+
+function Op(name, fn) {
+	this.name=name;
+	this.fn=fn;
+} Op.prototype.clone = function() { var c = Object.create(Op.prototype); c.name = this.name; c.fn = this.fn; return c; }; var Op$type = g.Type("main.Op", g.Uncomparable);
+
+function Server(handle, ops) {
+	this.handle=handle;
+	this.ops=ops;
+} Server.prototype.clone = function() { var c = Object.create(Server.prototype); c.handle = this.handle; c.ops = this.ops; return c; }; var Server$type = g.Type("main.Server", g.Uncomparable);
+
+function double(n) { return "" + n * 2 + ""; }
+
+function apply(h, n) { return g.Func(h)(n); }
+
+function adder(x) {
+	return function(y) { return x + y; };
+}
+
+function namedType() {
+	var h = double;
+	var inline = function(n) { return "n"; };
+
+	if (g.Func(h)(2) === "4" && apply(double, 3) === "6" && apply(inline, 1) === "n" && g.Func(double)(4) === "8") {
+		console.log("[OK] named type\n");
+	} else {
+		alert(g.Decode("[Error] named type: " + g.Func(h)(2) + " " + apply(double, 3) + " " + apply(inline, 1) + "\n"));
+	}
+
+
+	var add = adder(1);
+
+	if (g.Func(add)(2) === 3 && g.Func(adder(2))(3) === 5 && (function() { return 7; })() === 7) {
+		console.log("[OK] function result\n");
+	} else {
+		alert("[Error] function result: " + g.Func(add)(2) + " " + g.Func(adder(2))(3) + "\n");
+	}
+}
+
+function fields() {
+	var s = new Server(undefined, new g.S([], 0, 0)); s.handle = double;
+	s.ops = g.NewSlice([
+		new Op("add", function(a, b) { return a + b; }),
+		new Op("sub", function(a, b) { return a - b; })
+	], 0);
+
+	if (g.Func(s.handle)(5) === "10" && g.Func(s.ops.f[0].fn)(5, 2) === 7 && g.Func(s.ops.f[1].fn)(5, 2) === 3 && s.ops.f[1].name === "sub") {
+		console.log("[OK] struct field\n");
+	} else {
+		alert(g.Decode("[Error] struct field: " + g.Func(s.handle)(5) + " " + g.Func(s.ops.f[0].fn)(5, 2) + " " + g.Func(s.ops.f[1].fn)(5, 2) + "\n"));
+	}
+
+
+	s.handle = function(n) { return "changed"; };
+	var copied = s.clone();
+	copied.handle = undefined;
+
+	if (g.Func(s.handle)(1) === "changed" && copied.handle === undefined) {
+		console.log("[OK] assignment, copy\n");
+	} else {
+		alert(g.Decode("[Error] assignment, copy: " + g.Func(s.handle)(1) + " " + (copied.handle === undefined) + "\n"));
+	}
+}
+
+function collections() {
+	var fs = g.NewSlice([function() { return "a"; }, function() { return "b"; }], 0);
+	var byName = g.NewMap(undefined, [
+		["inc", function(n) { return n + 1; }],
+		["dec", function(n) { return n - 1; }]
+	]);
+	byName.entry("neg")[1] = function(n) { return -n; };
+
+	var sum = 0;
+	var f; for (var _e1 of (byName || g.NilMap(undefined)).f.values()) { f = _e1[1];
+		sum += g.Func(f)(10);
+	}
+
+	if (g.Func(fs.f[0])() + g.Func(fs.f[1])() === "ab" && g.Func((byName || g.NilMap(undefined)).get("inc")[0])(1) === 2 && g.Func((byName || g.NilMap(undefined)).get("neg")[0])(1) === -1 && sum === 10) {
+		console.log("[OK] slice, map\n");
+	} else {
+		alert(g.Decode("[Error] slice, map: " + g.Func(fs.f[0])() + " " + g.Func((byName || g.NilMap(undefined)).get("inc")[0])(1) + " " + sum + "\n"));
+	}
+}
+
+function nilFunc() {
+	var h = undefined;
+	var op = new Op("", undefined);
+	var f = undefined;
+	var byName = g.NewMap(undefined, []);
+
+	if (h === undefined && op.fn === undefined && f === undefined && (byName || g.NilMap(undefined)).get("none")[0] === undefined) {
+		console.log("[OK] nil\n");
+	} else {
+		alert("[Error] nil: " + (h === undefined) + " " + (op.fn === undefined) + " " + (f === undefined) + "\n");
+	}
+
+
+	h = double;
+	f = function() { };
+	g.Func(f)();
+
+	if (h !== undefined && f !== undefined) {
+		console.log("[OK] not nil\n");
+	} else {
+		alert("[Error] not nil: " + (h !== undefined) + " " + (f !== undefined) + "\n");
+	}
+}
+
+function main() {
+	console.log("\n== namedType\n");
+	namedType();
+	console.log("\n== fields\n");
+	fields();
+	console.log("\n== collections\n");
+	collections();
+	console.log("\n== nilFunc\n");
+	nilFunc();
+}
+//# sourceMappingURL=func_type.js.map
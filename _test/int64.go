@@ -0,0 +1,132 @@
+package main
+
+import "fmt"
+
+const big int64 = 1 << 62
+
+func arithmetic() {
+	var a int64 = 1 << 40
+	b := int64(3)
+	// Checking
+	if a+b == 1099511627779 && a-b == 1099511627773 && a*b == 3298534883328 {
+		println("[OK] add, sub, mul")
+	} else {
+		fmt.Println("[Error] add, sub, mul:", a+b, a-b, a*b)
+	}
+	//==
+
+	c := big
+	c *= 2
+	d := c - 1
+	// Checking
+	if c == -1<<63 && d == 1<<63-1 && d+1 == c {
+		println("[OK] overflow")
+	} else {
+		fmt.Println("[Error] overflow:", c, d)
+	}
+	//==
+
+	x, y := int64(-7), int64(2)
+	// Checking
+	if x/y == -3 && x%y == -1 && -x/y == 3 {
+		println("[OK] division")
+	} else {
+		fmt.Println("[Error] division:", x/y, x%y, -x/y)
+	}
+	//==
+
+	n := big
+	n++
+	n += 10
+	n *= 2
+	// Checking
+	if n == -9223372036854775786 {
+		println("[OK] assignment")
+	} else {
+		fmt.Println("[Error] assignment:", n)
+	}
+	//==
+}
+
+func bitwise() {
+	var u uint64 = 0xFFFFFFFF00000000
+	v := uint64(0x0F0F0F0F0F0F0F0F)
+	// Checking
+	if u&v == 0x0F0F0F0F00000000 && u|v == 0xFFFFFFFF0F0F0F0F &&
+		u^v == 0xF0F0F0F00F0F0F0F && u&^v == 0xF0F0F0F000000000 && ^u == 0xFFFFFFFF {
+		println("[OK] unsigned")
+	} else {
+		fmt.Println("[Error] unsigned:", u&v, u|v, u^v, u&^v, ^u)
+	}
+	//==
+
+	i := int64(-1)
+	s := uint(40)
+	// Checking
+	if i<<s == -1<<40 && i>>s == -1 && u>>s == 0xFFFFFF && u<<s == 0 && u>>1>>63 == 0 {
+		println("[OK] shift")
+	} else {
+		fmt.Println("[Error] shift:", i<<s, i>>s, u>>s, u<<s)
+	}
+	//==
+}
+
+func comparison() {
+	a, b := int64(-1), int64(1)
+	u, v := uint64(1<<63), uint64(1)
+	// Checking
+	if a < b && b > a && a <= a && u > v && v < u && a != b {
+		println("[OK] ordering")
+	} else {
+		fmt.Println("[Error] ordering:", a < b, u > v)
+	}
+	//==
+}
+
+func conversion() {
+	f := -3.75
+	i := int64(f)
+	n := 1 << 50
+	// Checking
+	if i == -3 && int64(n) == 1<<50 && int(i) == -3 && float64(int64(n)) == 1<<50 {
+		println("[OK] number")
+	} else {
+		fmt.Println("[Error] number:", i, int64(n), int(i))
+	}
+	//==
+
+	j := int64(-1)
+	// Checking
+	if uint64(j) == 0xFFFFFFFFFFFFFFFF && uint8(j) == 255 && int32(j) == -1 &&
+		int64(uint64(j)) == -1 {
+		println("[OK] integer")
+	} else {
+		fmt.Println("[Error] integer:", uint64(j), uint8(j), int32(j))
+	}
+	//==
+}
+
+func printing() {
+	var max uint64 = 1<<64 - 1
+	min := int64(-1 << 63)
+	// Checking
+	if fmt.Sprint(max) == "18446744073709551615" && fmt.Sprint(min) == "-9223372036854775808" {
+		println("[OK] decimal")
+	} else {
+		fmt.Println("[Error] decimal:", max, min)
+	}
+	//==
+}
+
+func main() {
+	println("\n== arithmetic")
+	arithmetic()
+	println("\n== bitwise")
+	bitwise()
+	println("\n== comparison")
+	comparison()
+	println("\n== conversion")
+	conversion()
+	println("\n== printing")
+	printing()
+}
@@ -0,0 +1,133 @@
+/* Generated by GoScript <github.com/kless/GoScript> */
+
+
+
+const big = new g.Int64(1073741824, 0);
+
+function arithmetic() {
+	var a = new g.Int64(256, 0);
+	var b = new g.Int64(0, 3);
+
+	if (a.add(b).eq(new g.Int64(256, 3)) && a.sub(b).eq(new g.Int64(255, 4294967293)) && a.mul(b).eq(new g.Int64(768, 0))) {
+		console.log("[OK] add, sub, mul\n");
+	} else {
+		alert("[Error] add, sub, mul: " + a.add(b).toString() + " " + a.sub(b).toString() + " " + a.mul(b).toString() + "\n");
+	}
+
+
+	var c = new g.Int64(1073741824, 0);
+	c = c.mul(new g.Int64(0, 2));
+	var d = c.sub(new g.Int64(0, 1));
+
+	if (c.eq(new g.Int64(2147483648, 0)) && d.eq(new g.Int64(2147483647, 4294967295)) && d.add(new g.Int64(0, 1)).eq(c)) {
+		console.log("[OK] overflow\n");
+	} else {
+		alert("[Error] overflow: " + c.toString() + " " + d.toString() + "\n");
+	}
+
+
+	var x = new g.Int64(4294967295, 4294967289), y = new g.Int64(0, 2);
+
+	if (x.div(y).eq(new g.Int64(4294967295, 4294967293)) && x.rem(y).eq(new g.Int64(4294967295, 4294967295)) && x.neg().div(y).eq(new g.Int64(0, 3))) {
+		console.log("[OK] division\n");
+	} else {
+		alert("[Error] division: " + x.div(y).toString() + " " + x.rem(y).toString() + " " + x.neg().div(y).toString() + "\n");
+	}
+
+
+	var n = new g.Int64(1073741824, 0);
+	n = n.add(new g.Int64(0, 1));
+	n = n.add(new g.Int64(0, 10));
+	n = n.mul(new g.Int64(0, 2));
+
+	if (n.eq(new g.Int64(2147483648, 22))) {
+		console.log("[OK] assignment\n");
+	} else {
+		alert("[Error] assignment: " + n.toString() + "\n");
+	}
+
+}
+
+function bitwise() {
+	var u = new g.Uint64(4294967295, 0);
+	var v = new g.Uint64(252645135, 252645135);
+
+	if (u.and(v).eq(new g.Uint64(252645135, 0)) && u.or(v).eq(new g.Uint64(4294967295, 252645135)) && u.xor(v).eq(new g.Uint64(4042322160, 252645135)) && u.andNot(v).eq(new g.Uint64(4042322160, 0)) && u.not().eq(new g.Uint64(0, 4294967295))) {
+
+		console.log("[OK] unsigned\n");
+	} else {
+		alert("[Error] unsigned: " + u.and(v).toString() + " " + u.or(v).toString() + " " + u.xor(v).toString() + " " + u.andNot(v).toString() + " " + u.not().toString() + "\n");
+	}
+
+
+	var i = new g.Int64(4294967295, 4294967295);
+	var s = 40;
+
+	if (i.shl(s).eq(new g.Int64(4294967040, 0)) && i.shr(s).eq(new g.Int64(4294967295, 4294967295)) && u.shr(s).eq(new g.Uint64(0, 16777215)) && u.shl(s).eq(new g.Uint64(0, 0)) && u.shr(1).shr(63).eq(new g.Uint64(0, 0))) {
+		console.log("[OK] shift\n");
+	} else {
+		alert("[Error] shift: " + i.shl(s).toString() + " " + i.shr(s).toString() + " " + u.shr(s).toString() + " " + u.shl(s).toString() + "\n");
+	}
+
+}
+
+function comparison() {
+	var a = new g.Int64(4294967295, 4294967295), b = new g.Int64(0, 1);
+	var u = new g.Uint64(2147483648, 0), v = new g.Uint64(0, 1);
+
+	if (a.cmp(b) < 0 && b.cmp(a) > 0 && a.cmp(a) <= 0 && u.cmp(v) > 0 && v.cmp(u) < 0 && !a.eq(b)) {
+		console.log("[OK] ordering\n");
+	} else {
+		alert("[Error] ordering: " + (a.cmp(b) < 0) + " " + (u.cmp(v) > 0) + "\n");
+	}
+
+}
+
+function conversion() {
+	var f = -3.75;
+	var i = g.ToInt64(f);
+	var n = 1125899906842624;
+
+	if (i.eq(new g.Int64(4294967295, 4294967293)) && g.ToInt64(n).eq(new g.Int64(262144, 0)) && i.toNumber() === -3 && g.ToInt64(n).toNumber() === 1.125899906842624e+15) {
+		console.log("[OK] number\n");
+	} else {
+		alert("[Error] number: " + i.toString() + " " + g.ToInt64(n).toString() + " " + i.toNumber() + "\n");
+	}
+
+
+	var j = new g.Int64(4294967295, 4294967295);
+
+	if (j.toUint64().eq(new g.Uint64(4294967295, 4294967295)) && (j.lo & 255) === 255 && (j.lo | 0) === -1 && j.toUint64().toInt64().eq(new g.Int64(4294967295, 4294967295))) {
+
+		console.log("[OK] integer\n");
+	} else {
+		alert("[Error] integer: " + j.toUint64().toString() + " " + (j.lo & 255) + " " + (j.lo | 0) + "\n");
+	}
+
+}
+
+function printing() {
+	var max = new g.Uint64(4294967295, 4294967295);
+	var min = new g.Int64(2147483648, 0);
+
+	if (max.toString() === "18446744073709551615" && min.toString() === "-9223372036854775808") {
+		console.log("[OK] decimal\n");
+	} else {
+		alert("[Error] decimal: " + max.toString() + " " + min.toString() + "\n");
+	}
+
+}
+
+function main() {
+	console.log("\n== arithmetic\n");
+	arithmetic();
+	console.log("\n== bitwise\n");
+	bitwise();
+	console.log("\n== comparison\n");
+	comparison();
+	console.log("\n== conversion\n");
+	conversion();
+	console.log("\n== printing\n");
+	printing();
+}
+//# sourceMappingURL=int64.js.map
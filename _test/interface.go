@@ -0,0 +1,174 @@
+package main
+
+import "fmt"
+
+type Shape interface {
+	Area() float64
+	Perimeter() float64
+}
+
+type Named interface {
+	Name() string
+}
+
+type rect struct{ w, h float64 }
+
+func (r rect) Area() float64      { return r.w * r.h }
+func (r rect) Perimeter() float64 { return 2 * (r.w + r.h) }
+func (r rect) Name() string       { return "rect" }
+
+type square struct{ side float64 }
+
+func (s square) Area() float64      { return s.side * s.side }
+func (s square) Perimeter() float64 { return 4 * s.side }
+
+func total(shapes []Shape) float64 {
+	sum := 0.0
+	for _, s := range shapes {
+		sum += s.Area()
+	}
+	return sum
+}
+
+func largest(a, b Shape) Shape {
+	if a.Area() >= b.Area() {
+		return a
+	}
+	return b
+}
+
+func newShape(side float64) Shape {
+	if side == 0 {
+		return nil
+	}
+	return square{side}
+}
+
+func dispatch() {
+	var s Shape = rect{2, 3}
+	shapes := []Shape{s, square{2}}
+	// Checking
+	if s.Area() == 6 && total(shapes) == 10 && largest(rect{1, 1}, square{2}).Perimeter() == 8 {
+		println("[OK] dynamic dispatch")
+	} else {
+		fmt.Println("[Error] dynamic dispatch:", s.Area(), total(shapes))
+	}
+	//==
+
+	r := rect{1, 1}
+	s = r
+	r.w = 10
+	// Checking
+	if s.Area() == 1 && r.Area() == 10 {
+		println("[OK] copy")
+	} else {
+		fmt.Println("[Error] copy:", s.Area(), r.Area())
+	}
+	//==
+
+	byName := map[string]Shape{"square": square{3}}
+	// Checking
+	if byName["square"].Area() == 9 && newShape(1).Area() == 1 {
+		println("[OK] map, return")
+	} else {
+		fmt.Println("[Error] map, return:", byName["square"].Area())
+	}
+}
+
+func nilInterface() {
+	var s Shape
+	var n Named
+	// Checking
+	if s == nil && n == nil && newShape(0) == nil {
+		println("[OK] nil")
+	} else {
+		fmt.Println("[Error] nil:", s, n)
+	}
+	//==
+
+	s = rect{1, 2}
+	var any interface{} = s
+	// Checking
+	if s != nil && any == s && any != (square{1}) && any == (rect{1, 2}) {
+		println("[OK] not nil")
+	} else {
+		fmt.Println("[Error] not nil:", s != nil, any == s)
+	}
+	//==
+
+	var i, f interface{} = 1, 1.0
+	// Checking
+	if i != f && i == 1 && f == 1.0 {
+		println("[OK] dynamic type")
+	} else {
+		fmt.Println("[Error] dynamic type:", i, f)
+	}
+}
+
+func assertion() {
+	var s Shape = square{3}
+	sq := s.(square)
+	sq.side = 4
+	_, isRect := s.(rect)
+	n, isNamed := s.(Named)
+	// Checking
+	if sq.side == 4 && s.Area() == 9 && !isRect && !isNamed && n == nil {
+		println("[OK] concrete type")
+	} else {
+		fmt.Println("[Error] concrete type:", sq.side, isRect, isNamed)
+	}
+	//==
+
+	var any interface{} = rect{1, 2}
+	n, isNamed = any.(Named)
+	shape := any.(Shape)
+	// Checking
+	if isNamed && n.Name() == "rect" && shape.Perimeter() == 6 {
+		println("[OK] interface type")
+	} else {
+		fmt.Println("[Error] interface type:", isNamed)
+	}
+}
+
+func describe(x interface{}) string {
+	switch v := x.(type) {
+	case nil:
+		return "nil"
+	case int:
+		if v == 2 {
+			return "int"
+		}
+	case string, bool:
+		return "string or bool"
+	case Named:
+		return v.Name()
+	case Shape:
+		if v.Area() == 4 {
+			return "shape"
+		}
+	}
+	return "other"
+}
+
+func typeSwitch() {
+	var s Shape
+	// Checking
+	if describe(nil) == "nil" && describe(2) == "int" && describe(2.0) == "other" &&
+		describe(true) == "string or bool" && describe(rect{}) == "rect" &&
+		describe(square{2}) == "shape" && describe(s) == "nil" {
+		println("[OK] type switch")
+	} else {
+		fmt.Println("[Error] type switch:", describe(2), describe(2.0), describe(rect{}))
+	}
+}
+
+func main() {
+	println("\n== dispatch")
+	dispatch()
+	println("\n== nilInterface")
+	nilInterface()
+	println("\n== assertion")
+	assertion()
+	println("\n== typeSwitch")
+	typeSwitch()
+}
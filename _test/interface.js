@@ -0,0 +1,175 @@
+/* Generated by GoScript <github.com/kless/GoScript> */
+
+
+
+var Shape$type = g.Iface("main.Shape", ["Area() float64", "Perimeter() float64"]);
+
+
+
+
+var Named$type = g.Iface("main.Named", ["Name() string"]);
+
+
+
+function rect(w, h) { this.w=w; this.h=h; } rect.prototype.clone = function() { var c = Object.create(rect.prototype); c.w = this.w; c.h = this.h; return c; }; rect.prototype.eq = function(y) { return this.w === y.w && this.h === y.h; }; var rect$type = g.Type("main.rect", function(x, y) { return x.eq(y); }, ["Area() float64", "Name() string", "Perimeter() float64"]);
+
+rect.prototype.Area = function() { return this.w * this.h; }
+rect.prototype.Perimeter = function() { return 2 * (this.w + this.h); }
+rect.prototype.Name = function() { return "rect"; }
+
+function square(side) { this.side=side; } square.prototype.clone = function() { var c = Object.create(square.prototype); c.side = this.side; return c; }; square.prototype.eq = function(y) { return this.side === y.side; }; var square$type = g.Type("main.square", function(x, y) { return x.eq(y); }, ["Area() float64", "Perimeter() float64"]);
+
+square.prototype.Area = function() { return this.side * this.side; }
+square.prototype.Perimeter = function() { return 4 * this.side; }
+
+function total(shapes) {
+	var sum = 0.0;
+	var s; for (var _ in shapes.f) { s = shapes.f[_];
+		sum += s.Area();
+	}
+	return sum;
+}
+
+function largest(a, b) {
+	if (a.Area() >= b.Area()) {
+		return a;
+	}
+	return b;
+}
+
+function newShape(side) {
+	if (side === 0) {
+		return undefined;
+	}
+	return g.Box(new square(side), square$type);
+}
+
+function dispatch() {
+	var s = g.Box(new rect(2, 3), rect$type);
+	var shapes = g.NewSlice([s, g.Box(new square(2), square$type)], 0);
+
+	if (s.Area() === 6 && total(shapes) === 10 && largest(g.Box(new rect(1, 1), rect$type), g.Box(new square(2), square$type)).Perimeter() === 8) {
+		console.log("[OK] dynamic dispatch\n");
+	} else {
+		alert("[Error] dynamic dispatch: " + s.Area() + " " + total(shapes) + "\n");
+	}
+
+
+	var r = new rect(1, 1);
+	s = g.Box(r.clone(), rect$type);
+	r.w = 10;
+
+	if (s.Area() === 1 && r.Area() === 10) {
+		console.log("[OK] copy\n");
+	} else {
+		alert("[Error] copy: " + s.Area() + " " + r.Area() + "\n");
+	}
+
+
+	var byName = g.NewMap(undefined, [["square", g.Box(new square(3), square$type)]]);
+
+	if ((byName || g.NilMap(undefined)).get("square")[0].Area() === 9 && newShape(1).Area() === 1) {
+		console.log("[OK] map, return\n");
+	} else {
+		alert("[Error] map, return: " + (byName || g.NilMap(undefined)).get("square")[0].Area() + "\n");
+	}
+}
+
+function nilInterface() {
+	var s = undefined;
+	var n = undefined;
+
+	if (s === undefined && n === undefined && newShape(0) === undefined) {
+		console.log("[OK] nil\n");
+	} else {
+		alert(g.Decode("[Error] nil: " + s + " " + n + "\n"));
+	}
+
+
+	s = g.Box(new rect(1, 2), rect$type);
+	var any = s;
+
+	if (s !== undefined && g.Equal(any, s) && !g.Equal(any, g.Box((new square(1)), square$type)) && g.Equal(any, g.Box((new rect(1, 2)), rect$type))) {
+		console.log("[OK] not nil\n");
+	} else {
+		alert("[Error] not nil: " + (s !== undefined) + " " + g.Equal(any, s) + "\n");
+	}
+
+
+	var i = g.Box(1, g.Type("int")), f = g.Box(1.0, g.Type("float64"));
+
+	if (!g.Equal(i, f) && g.Equal(i, g.Box(1, g.Type("int"))) && g.Equal(f, g.Box(1.0, g.Type("float64")))) {
+		console.log("[OK] dynamic type\n");
+	} else {
+		alert(g.Decode("[Error] dynamic type: " + i + " " + f + "\n"));
+	}
+}
+
+function assertion() {
+	var s = g.Box(new square(3), square$type);
+	var sq = g.Assert(s, square$type).clone();
+	sq.side = 4;
+	var isRect = g.AssertOk(s, rect$type, new rect(0, 0))[1];
+	var _ = g.AssertOk(s, Named$type, undefined), n = _[0], isNamed = _[1];
+
+	if (sq.side === 4 && s.Area() === 9 && !isRect && !isNamed && n === undefined) {
+		console.log("[OK] concrete type\n");
+	} else {
+		alert("[Error] concrete type: " + sq.side + " " + isRect + " " + isNamed + "\n");
+	}
+
+
+	var any = g.Box(new rect(1, 2), rect$type);
+	_ = g.AssertOk(any, Named$type, undefined), n = _[0], isNamed = _[1];
+	var shape = g.Assert(any, Shape$type);
+
+	if (isNamed && n.Name() === "rect" && shape.Perimeter() === 6) {
+		console.log("[OK] interface type\n");
+	} else {
+		alert("[Error] interface type: " + isNamed + "\n");
+	}
+}
+
+function describe(x) {
+	var _x1 = x; switch (true) {
+	case _x1 === undefined: var v = _x1;
+		return "nil";
+	case g.Is(_x1, g.Type("int")): var v = _x1.v;
+		if (v === 2) {
+		return "int";
+	} break;
+	case g.Is(_x1, g.Type("string")): case g.Is(_x1, g.Type("bool")): var v = _x1;
+		return "string or bool";
+	case g.Is(_x1, Named$type): var v = _x1;
+		return v.Name();
+	case g.Is(_x1, Shape$type): var v = _x1;
+		if (v.Area() === 4) {
+		return "shape";
+	}
+	}
+	return "other";
+}
+
+function typeSwitch() {
+	var s = undefined;
+
+	if (describe(undefined) === "nil" && describe(g.Box(2, g.Type("int"))) === "int" && describe(g.Box(2.0, g.Type("float64"))) === "other" && describe(g.Box(true, g.Type("bool"))) === "string or bool" && describe(g.Box(new rect(0, 0), rect$type)) === "rect" && describe(g.Box(new square(2), square$type)) === "shape" && describe(s) === "nil") {
+
+
+		console.log("[OK] type switch\n");
+	} else {
+		alert(g.Decode("[Error] type switch: " + describe(g.Box(2, g.Type("int"))) + " " + describe(g.Box(2.0, g.Type("float64"))) + " " + describe(g.Box(new rect(0, 0), rect$type)) + "\n"));
+	}
+}
+
+function main() {
+	console.log("\n== dispatch\n");
+	dispatch();
+	console.log("\n== nilInterface\n");
+	nilInterface();
+	console.log("\n== assertion\n");
+	assertion();
+	console.log("\n== typeSwitch\n");
+	typeSwitch();
+}
+//# sourceMappingURL=interface.js.map
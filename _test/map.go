@@ -16,6 +16,22 @@ func valueNil() {
 	}
 	//==
 
+	v, ok := n["a"]
+	iterations := 0
+	for range n {
+		iterations++
+	}
+	delete(n, "a")
+
+	// Checking
+	msg = "reading"
+	if n["a"] == 0 && v == 0 && !ok && len(n) == 0 && iterations == 0 {
+		println("[OK]", msg)
+	} else {
+		fmt.Println("[Error]", msg, n["a"], v, ok, len(n), iterations)
+	}
+	//==
+
 	n = make(map[string]int)
 
 	// Checking
@@ -2,7 +2,7 @@
 
 
 
-var rating = new g.M({"C": 5, "Go": 4.5, "Python": 4.5, "C++": 2}, 0);
+var rating = g.NewMap(0, [["C", 5], ["Go", 4.5], ["Python", 4.5], ["C++", 2]]);
 
 function valueNil() {
 	var n;
@@ -10,20 +10,36 @@ function valueNil() {
 
 	var msg = "declaration";
 	if (n === undefined) {
-		console.log("[OK] " + msg + "\n");
+		console.log(g.Decode("[OK] " + msg + "\n"));
 	} else {
-		alert("[Error] " + msg + "\n");
+		alert(g.Decode("[Error] " + msg + "\n"));
 	}
 
 
-	n = new g.M({}, 0);
+	var _ = (n || g.NilMap(0)).get("a"), v = _[0], ok = _[1];
+	var iterations = 0;
+	for (var _e1 of (n || g.NilMap(0)).f.values()) {
+		iterations++;
+	}
+	(n || g.NilMap(0)).delete("a");
+
+
+	msg = "reading";
+	if ((n || g.NilMap(0)).get("a")[0] === 0 && v === 0 && !ok && (n || g.NilMap(0)).len() === 0 && iterations === 0) {
+		console.log(g.Decode("[OK] " + msg + "\n"));
+	} else {
+		alert(g.Decode("[Error] " + msg + " " + (n || g.NilMap(0)).get("a")[0] + " " + v + " " + ok + " " + (n || g.NilMap(0)).len() + " " + iterations + "\n"));
+	}
+
+
+	n = g.NewMap(0);
 
 
 	msg = "using make";
 	if (n !== undefined) {
-		console.log("[OK] " + msg + "\n");
+		console.log(g.Decode("[OK] " + msg + "\n"));
 	} else {
-		alert("[Error] " + msg + "\n");
+		alert(g.Decode("[Error] " + msg + "\n"));
 	}
 
 }
@@ -32,109 +48,109 @@ function declare_1() {
 
 
 	var numbers;
-	numbers = new g.M({}, 0);
+	numbers = g.NewMap(0);
 
-	numbers.f["one"] = 1;
-	numbers.f["ten"] = 10;
-	numbers.f["trois"] = 3;
+	numbers.entry("one")[1] = 1;
+	numbers.entry("ten")[1] = 10;
+	numbers.entry("trois")[1] = 3;
 
 
-	if (numbers.get("trois")[0] === 3) {
+	if ((numbers || g.NilMap(0)).get("trois")[0] === 3) {
 		console.log("[OK]\n");
 	} else {
-		alert("[Error] Trois is the french word for the number: " + numbers.get("trois")[0] + "\n");
+		alert("[Error] Trois is the french word for the number: " + (numbers || g.NilMap(0)).get("trois")[0] + "\n");
 	}
 
 }
 
 function declare_2() {
 
-	var rating2 = new g.M({"C": 5, "Go": 4.5, "Python": 4.5, "C++": 2}, 0);
+	var rating2 = g.NewMap(0, [["C", 5], ["Go", 4.5], ["Python", 4.5], ["C++", 2]]);
 
 
-	var rating = new g.M({}, 0);
-	rating.f["C"] = 5;
-	rating.f["Go"] = 4.5;
-	rating.f["Python"] = 4.5;
-	rating.f["C++"] = 2;
+	var rating = g.NewMap(0);
+	rating.entry("C")[1] = 5;
+	rating.entry("Go")[1] = 4.5;
+	rating.entry("Python")[1] = 4.5;
+	rating.entry("C++")[1] = 2;
 
 
 	var code = "";
-	if (JSON.stringify(rating.get("Go")[0]) === JSON.stringify(rating2.get("Go")[0])) {
+	if ((rating || g.NilMap(0)).get("Go")[0] === (rating2 || g.NilMap(0)).get("Go")[0]) {
 		console.log("[OK] comparing same value\n");
 	} else {
-		alert("[Error] rating[\"Go\"]: " + rating.get("Go")[0] + "\trating2[\"Go\"]: " + rating2.get("Go")[0] + "\n");
+		alert("[Error] rating[\"Go\"]: " + g.FormatFloat((rating || g.NilMap(0)).get("Go")[0], 32) + "\trating2[\"Go\"]: " + g.FormatFloat((rating2 || g.NilMap(0)).get("Go")[0], 32) + "\n");
 
 	}
 
 
-	rating.f["Go"] = 4.7;
+	rating.entry("Go")[1] = 4.699999809265137;
 
-	if (JSON.stringify(rating.get("Go")[0]) !== JSON.stringify(rating2.get("Go")[0])) {
+	if ((rating || g.NilMap(0)).get("Go")[0] !== (rating2 || g.NilMap(0)).get("Go")[0]) {
 		code = "OK";
 	} else {
 		code = "Error";
 	}
-	console.log("[" + code + "] comparing different value\n");
+	console.log(g.Decode("[" + code + "] comparing different value\n"));
 
 }
 
 function reference() {
 
-	var m = new g.M({}, "");
-	m.f["Hello"] = "Bonjour";
+	var m = g.NewMap("");
+	m.entry("Hello")[1] = "Bonjour";
 
 	var m1 = m;
-	m1.f["Hello"] = "Salut";
+	m1.entry("Hello")[1] = "Salut";
 
 
-	if (JSON.stringify(m.get("Hello")[0]) === JSON.stringify(m1.get("Hello")[0])) {
+	if ((m || g.NilMap("")).get("Hello")[0] === (m1 || g.NilMap("")).get("Hello")[0]) {
 		console.log("[OK]\n");
 	} else {
-		alert("[Error] value in key: " + m.get("Hello")[0] + "\n");
+		alert(g.Decode("[Error] value in key: " + (m || g.NilMap("")).get("Hello")[0] + "\n"));
 	}
 
 }
 
 function checkKey() {
-	var csharp_rating = rating.get("C#")[0];
+	var csharp_rating = (rating || g.NilMap(0)).get("C#")[0];
 
-	if (csharp_rating === 0.00) {
+	if (csharp_rating === 0) {
 		console.log("[OK] single key\n");
 	} else {
-		alert("[Error] value in key: " + csharp_rating + "\n");
+		alert("[Error] value in key: " + g.FormatFloat(csharp_rating, 32) + "\n");
 	}
 
 
-	var multMap = new g.M({1: {1: "one"}, 2: {2: "two"}}, "");
-	var k_multMap = multMap.get(1, 2)[0];
+	var multMap = g.NewMap(undefined, [[1, g.NewMap("", [[1, "one"]])], [2, g.NewMap("", [[2, "two"]])]]);
+	var k_multMap = ((multMap || g.NilMap(undefined)).get(1)[0] || g.NilMap("")).get(2)[0];
 
 	if (k_multMap === "") {
 		console.log("[OK] multi-dimensional key\n");
 	} else {
-		alert("[Error] value in multi-dimensional key: " + k_multMap + "\n");
+		alert(g.Decode("[Error] value in multi-dimensional key: " + k_multMap + "\n"));
 	}
 
 
-	var _ = rating.get("C#"), csharp_rating2 = _[0], ok = _[1];
+	var _ = (rating || g.NilMap(0)).get("C#"), csharp_rating2 = _[0], ok = _[1];
 
 	if (ok) {
 		alert("[Error] using comma\n");
 	} else {
 		console.log("[OK] using comma\n");
 	}
-	if (csharp_rating2 === 0.00) {
+	if (csharp_rating2 === 0) {
 		console.log("[OK] value (using comma)\n");
 	} else {
-		alert("[Error] value in key (using comma): " + csharp_rating2 + "\n");
+		alert("[Error] value in key (using comma): " + g.FormatFloat(csharp_rating2, 32) + "\n");
 	}
 
 }
 
 function deleteKey() {
-	delete rating.f["C++"];
+	(rating || g.NilMap(0)).delete("C++");
 
-	var ok = rating.get("C++")[1];
+	var ok = (rating || g.NilMap(0)).get("C++")[1];
 
 	if (ok) {
 		alert("[Error]\n");
@@ -148,25 +164,25 @@ function testRange() {
 	var hasError = false;
 
 
-	var value; for (key in rating.m) { value = rating.get(key)[0];
+	var value; for (var _e1 of (rating || g.NilMap(0)).f.values()) { var key = _e1[0]; value = _e1[1];
 		switch (key) {
 		case "C":
 			if (value !== 5) {
-			alert("[Error] key 'C': expected '5', got " + value + "\n");
+			alert("[Error] key 'C': expected '5', got " + g.FormatFloat(value, 32) + "\n");
 			hasError = true;
 		} break;
 		case "Go":
 			if (value !== 4.5) {
-			alert("[Error] key 'Go': expected '4.5', got " + value + "\n");
+			alert("[Error] key 'Go': expected '4.5', got " + g.FormatFloat(value, 32) + "\n");
 			hasError = true;
 		} break;
 		case "Python":
 			if (value !== 4.5) {
-			alert("[Error] key 'Python': expected '4.5', got " + value + "\n");
+			alert("[Error] key 'Python': expected '4.5', got " + g.FormatFloat(value, 32) + "\n");
 			hasError = true;
 		} break;
 		default:
-			alert("[Error] key not expected: " + key + "\n");
+			alert(g.Decode("[Error] key not expected: " + key + "\n"));
 			hasError = true;
 		}
 	}
@@ -175,9 +191,9 @@ function testRange() {
 	}
 
 
-	for (key in rating.m) {
+	for (var _e1 of (rating || g.NilMap(0)).f.values()) { var key = _e1[0];
 		if (key !== "C" && key !== "Go" && key !== "Python") {
-			alert("[Error] key not expected: " + key + "\n");
+			alert(g.Decode("[Error] key not expected: " + key + "\n"));
 			hasError = true;
 		}
 	}
@@ -191,8 +207,8 @@ function blankIdentifierInRange() {
 
 
 	var Max = function(slice) {
-		var max = slice[0];
-		var value; for (_ in slice) { value = slice[_];
+		var max = slice.f[0];
+		var value; for (var _ in slice.f) { value = slice.f[_];
 			if (value > max) {
 				max = value;
 			}
@@ -201,26 +217,26 @@ function blankIdentifierInRange() {
 	};
 
 
-	var A1 = []; for (var i=0; i<10; i++){ A1[i]=0; } A1 = [1, 2, 3, 4, 5, 6, 7, 8, 9];
-	var A2 = []; for (var i=0; i<4; i++){ A2[i]=0; } A2 = [1, 2, 3, 4];
-	var A3 = []; for (var i=0; i<1; i++){ A3[i]=0; } A3 = [1];
+	var A1 = [1, 2, 3, 4, 5, 6, 7, 8, 9, 0];
+	var A2 = [1, 2, 3, 4];
+	var A3 = [1];
 
 
 	var slice = new g.S([], 0, 0);
 
 	slice.set(A1, 0);
-	if (Max(slice.f) !== 9) {
-		alert("[Error] 'A1': value expected '9', got " + Max(slice.f) + "\n");
+	if (Max(slice) !== 9) {
+		alert("[Error] 'A1': value expected '9', got " + Max(slice) + "\n");
 		hasError = true;
 	}
 	slice.set(A2, 0);
-	if (Max(slice.f) !== 4) {
-		alert("[Error] 'A2': value expected '4', got " + Max(slice.f) + "\n");
+	if (Max(slice) !== 4) {
+		alert("[Error] 'A2': value expected '4', got " + Max(slice) + "\n");
 		hasError = true;
 	}
 	slice.set(A3, 0);
-	if (Max(slice.f) !== 1) {
-		alert("[Error] 'A3': value expected '1', got " + Max(slice.f) + "\n");
+	if (Max(slice) !== 1) {
+		alert("[Error] 'A3': value expected '1', got " + Max(slice) + "\n");
 		hasError = true;
 	}
 
@@ -247,3 +263,4 @@ function main() {
 	console.log("\n== blankIdentifierInRange\n");
 	blankIdentifierInRange();
 }
+//# sourceMappingURL=map.js.map
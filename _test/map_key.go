@@ -0,0 +1,170 @@
+package main
+
+import "fmt"
+
+type point struct{ x, y int }
+
+type label struct {
+	name string
+	at   point
+}
+
+func basic() {
+	m := map[interface{}]int{1: 1, "1": 2, true: 3}
+	// Checking
+	if len(m) == 3 && m[1] == 1 && m["1"] == 2 && m[true] == 3 {
+		println("[OK] dynamic type")
+	} else {
+		fmt.Println("[Error] dynamic type:", len(m), m[1], m["1"], m[true])
+	}
+	//==
+
+	big := map[int64]string{1 << 40: "big"}
+	var k int64 = 1 << 40
+	_, ok := big[k+1]
+	// Checking
+	if big[k] == "big" && !ok {
+		println("[OK] int64")
+	} else {
+		fmt.Println("[Error] int64:", big[k], ok)
+	}
+	//==
+
+	zero := 0.0
+	nan := zero / zero
+	f := make(map[float64]int)
+	f[nan] = 1
+	f[nan] = 2
+	f[-zero] = 3
+	_, found := f[nan]
+	// Checking
+	if len(f) == 3 && !found && f[0] == 3 {
+		println("[OK] NaN")
+	} else {
+		fmt.Println("[Error] NaN:", len(f), found, f[0])
+	}
+	//==
+}
+
+func composite() {
+	p := point{1, 2}
+	m := map[point]string{p: "p"}
+	m[point{3, 4}] = "q"
+	p.x = 10
+	// Checking
+	if m[point{1, 2}] == "p" && m[point{3, 4}] == "q" && m[p] == "" && len(m) == 2 {
+		println("[OK] struct")
+	} else {
+		fmt.Println("[Error] struct:", m[point{1, 2}], m[p], len(m))
+	}
+	//==
+
+	a := [2]int{1, 2}
+	b := [2]int{1, 2}
+	arrays := map[[2]int]bool{a: true}
+	a[0] = 5
+	// Checking
+	if arrays[b] && !arrays[a] {
+		println("[OK] array")
+	} else {
+		fmt.Println("[Error] array:", arrays[b], arrays[a])
+	}
+	//==
+
+	arrays[[2]int{3}] = true
+	_, found := arrays[[2]int{3, 0}]
+	// Checking
+	if found && arrays[[2]int{3, 0}] && len(arrays) == 2 {
+		println("[OK] array literal")
+	} else {
+		fmt.Println("[Error] array literal:", found, len(arrays))
+	}
+	//==
+
+	labels := make(map[label]int)
+	labels[label{"a", point{1, 1}}] = 1
+	labels[label{"a", point{1, 2}}] = 2
+	labels[label{"a", point{1, 1}}] = 3
+	// Checking
+	if len(labels) == 2 && labels[label{"a", point{1, 1}}] == 3 {
+		println("[OK] nested")
+	} else {
+		fmt.Println("[Error] nested:", len(labels))
+	}
+	//==
+
+	one, two := 1, 1
+	pointers := map[*int]string{&one: "one"}
+	_, ok := pointers[&two]
+	// Checking
+	if pointers[&one] == "one" && !ok {
+		println("[OK] pointer")
+	} else {
+		fmt.Println("[Error] pointer:", pointers[&one], ok)
+	}
+	//==
+
+	var any interface{} = point{1, 2}
+	dyn := map[interface{}]string{any: "point", b: "array"}
+	// Checking
+	if dyn[point{1, 2}] == "point" && dyn[b] == "array" && dyn[a] == "" {
+		println("[OK] dynamic value")
+	} else {
+		fmt.Println("[Error] dynamic value:", dyn[point{1, 2}], dyn[b])
+	}
+	//==
+}
+
+func operations() {
+	counts := make(map[string]int)
+	for _, w := range []string{"a", "b", "a", "c", "a"} {
+		counts[w]++
+	}
+	counts["b"] += 10
+	// Checking
+	if counts["a"] == 3 && counts["b"] == 11 && len(counts) == 3 {
+		println("[OK] update")
+	} else {
+		fmt.Println("[Error] update:", counts["a"], counts["b"], len(counts))
+	}
+	//==
+
+	delete(counts, "c")
+	delete(counts, "z")
+	_, ok := counts["c"]
+	// Checking
+	if !ok && len(counts) == 2 {
+		println("[OK] delete")
+	} else {
+		fmt.Println("[Error] delete:", ok, len(counts))
+	}
+	//==
+
+	// The deleted entries which are not reached are not produced.
+	squares := map[int]int{1: 1, 2: 4, 3: 9, 4: 16}
+	n := 0
+	for k := range squares {
+		for other := range squares {
+			if other != k {
+				delete(squares, other)
+			}
+		}
+		n++
+	}
+	// Checking
+	if n == 1 && len(squares) == 1 {
+		println("[OK] range")
+	} else {
+		fmt.Println("[Error] range:", n, len(squares))
+	}
+	//==
+}
+
+func main() {
+	println("\n== basic")
+	basic()
+	println("\n== composite")
+	composite()
+	println("\n== operations")
+	operations()
+}
@@ -0,0 +1,171 @@
+/* Generated by GoScript <github.com/kless/GoScript> */
+
+
+
+function point(x, y) { this.x=x; this.y=y; } point.prototype.clone = function() { var c = Object.create(point.prototype); c.x = this.x; c.y = this.y; return c; }; point.prototype.eq = function(y) { return this.x === y.x && this.y === y.y; }; var point$type = g.Type("main.point", function(x, y) { return x.eq(y); });
+
+function label(name, at) {
+	this.name=name;
+	this.at=at;
+} label.prototype.clone = function() { var c = Object.create(label.prototype); c.name = this.name; c.at = this.at.clone(); return c; }; label.prototype.eq = function(y) { return this.name === y.name && this.at.eq(y.at); }; var label$type = g.Type("main.label", function(x, y) { return x.eq(y); });
+
+function basic() {
+	var m = g.NewMap(0, [[g.Box(1, g.Type("int")), 1], [g.Box("1", g.Type("string")), 2], [g.Box(true, g.Type("bool")), 3]], function(k) { return g.Hash(g.Key(k)); });
+
+	if ((m || g.NilMap(0)).len() === 3 && (m || g.NilMap(0)).get(g.Box(1, g.Type("int")))[0] === 1 && (m || g.NilMap(0)).get(g.Box("1", g.Type("string")))[0] === 2 && (m || g.NilMap(0)).get(g.Box(true, g.Type("bool")))[0] === 3) {
+		console.log("[OK] dynamic type\n");
+	} else {
+		alert("[Error] dynamic type: " + (m || g.NilMap(0)).len() + " " + (m || g.NilMap(0)).get(g.Box(1, g.Type("int")))[0] + " " + (m || g.NilMap(0)).get(g.Box("1", g.Type("string")))[0] + " " + (m || g.NilMap(0)).get(g.Box(true, g.Type("bool")))[0] + "\n");
+	}
+
+
+	var big = g.NewMap("", [[new g.Int64(256, 0), "big"]], function(k) { return g.Hash(k); });
+	var k = new g.Int64(256, 0);
+	var ok = (big || g.NilMap("")).get(k.add(new g.Int64(0, 1)))[1];
+
+	if ((big || g.NilMap("")).get(k)[0] === "big" && !ok) {
+		console.log("[OK] int64\n");
+	} else {
+		alert(g.Decode("[Error] int64: " + (big || g.NilMap("")).get(k)[0] + " " + ok + "\n"));
+	}
+
+
+	var zero = 0.0;
+	var nan = zero / zero;
+	var f = g.NewMap(0);
+	f.entry(nan)[1] = 1;
+	f.entry(nan)[1] = 2;
+	f.entry(-zero)[1] = 3;
+	var found = (f || g.NilMap(0)).get(nan)[1];
+
+	if ((f || g.NilMap(0)).len() === 3 && !found && (f || g.NilMap(0)).get(0)[0] === 3) {
+		console.log("[OK] NaN\n");
+	} else {
+		alert("[Error] NaN: " + (f || g.NilMap(0)).len() + " " + found + " " + (f || g.NilMap(0)).get(0)[0] + "\n");
+	}
+
+}
+
+function composite() {
+	var p = new point(1, 2);
+	var m = g.NewMap("", [[p.clone(), "p"]], function(k) { return g.Hash([k.x, k.y]); });
+	m.entry(new point(3, 4))[1] = "q";
+	p.x = 10;
+
+	if ((m || g.NilMap("")).get(new point(1, 2))[0] === "p" && (m || g.NilMap("")).get(new point(3, 4))[0] === "q" && (m || g.NilMap("")).get(p)[0] === "" && (m || g.NilMap("")).len() === 2) {
+		console.log("[OK] struct\n");
+	} else {
+		alert(g.Decode("[Error] struct: " + (m || g.NilMap("")).get(new point(1, 2))[0] + " " + (m || g.NilMap("")).get(p)[0] + " " + (m || g.NilMap("")).len() + "\n"));
+	}
+
+
+	var a = [1, 2];
+	var b = [1, 2];
+	var arrays = g.NewMap(false, [[a.slice(), true]], function(k) { return g.Hash(k); });
+	a[0] = 5;
+
+	if ((arrays || g.NilMap(false)).get(b)[0] && !(arrays || g.NilMap(false)).get(a)[0]) {
+		console.log("[OK] array\n");
+	} else {
+		alert("[Error] array: " + (arrays || g.NilMap(false)).get(b)[0] + " " + (arrays || g.NilMap(false)).get(a)[0] + "\n");
+	}
+
+
+	arrays.entry([3, 0])[1] = true;
+	var found = (arrays || g.NilMap(false)).get([3, 0])[1];
+
+	if (found && (arrays || g.NilMap(false)).get([3, 0])[0] && (arrays || g.NilMap(false)).len() === 2) {
+		console.log("[OK] array literal\n");
+	} else {
+		alert("[Error] array literal: " + found + " " + (arrays || g.NilMap(false)).len() + "\n");
+	}
+
+
+	var labels = g.NewMap(0, [], function(k) { return g.Hash([k.name, [k.at.x, k.at.y]]); });
+	labels.entry(new label("a", new point(1, 1)))[1] = 1;
+	labels.entry(new label("a", new point(1, 2)))[1] = 2;
+	labels.entry(new label("a", new point(1, 1)))[1] = 3;
+
+	if ((labels || g.NilMap(0)).len() === 2 && (labels || g.NilMap(0)).get(new label("a", new point(1, 1)))[0] === 3) {
+		console.log("[OK] nested\n");
+	} else {
+		alert("[Error] nested: " + (labels || g.NilMap(0)).len() + "\n");
+	}
+
+
+	var one = {p:1}, two = {p:1};
+	var pointers = g.NewMap("", [[one, "one"]]);
+	var ok = (pointers || g.NilMap("")).get(two)[1];
+
+	if ((pointers || g.NilMap("")).get(one)[0] === "one" && !ok) {
+		console.log("[OK] pointer\n");
+	} else {
+		alert(g.Decode("[Error] pointer: " + (pointers || g.NilMap("")).get(one)[0] + " " + ok + "\n"));
+	}
+
+
+	var any = g.Box(new point(1, 2), point$type);
+	var dyn = g.NewMap("", [[any, "point"], [g.Box(b.slice(), g.Type("[2]int", function(x, y) { return g.ArrayEq(x, y, function(a, b) { return a === b; }); })), "array"]], function(k) { return g.Hash(g.Key(k)); });
+
+	if ((dyn || g.NilMap("")).get(g.Box(new point(1, 2), point$type))[0] === "point" && (dyn || g.NilMap("")).get(g.Box(b, g.Type("[2]int", function(x, y) { return g.ArrayEq(x, y, function(a, b) { return a === b; }); })))[0] === "array" && (dyn || g.NilMap("")).get(g.Box(a, g.Type("[2]int", function(x, y) { return g.ArrayEq(x, y, function(a, b) { return a === b; }); })))[0] === "") {
+		console.log("[OK] dynamic value\n");
+	} else {
+		alert(g.Decode("[Error] dynamic value: " + (dyn || g.NilMap("")).get(g.Box(new point(1, 2), point$type))[0] + " " + (dyn || g.NilMap("")).get(g.Box(b, g.Type("[2]int", function(x, y) { return g.ArrayEq(x, y, function(a, b) { return a === b; }); })))[0] + "\n"));
+	}
+
+}
+
+function operations() {
+	var counts = g.NewMap(0);
+	var w; for (var _ in g.NewSlice(["a", "b", "a", "c", "a"], 0).f) { w = g.NewSlice(["a", "b", "a", "c", "a"], 0).f[_];
+		counts.entry(w)[1]++;
+	}
+	counts.entry("b")[1] += 10;
+
+	if ((counts || g.NilMap(0)).get("a")[0] === 3 && (counts || g.NilMap(0)).get("b")[0] === 11 && (counts || g.NilMap(0)).len() === 3) {
+		console.log("[OK] update\n");
+	} else {
+		alert("[Error] update: " + (counts || g.NilMap(0)).get("a")[0] + " " + (counts || g.NilMap(0)).get("b")[0] + " " + (counts || g.NilMap(0)).len() + "\n");
+	}
+
+
+	(counts || g.NilMap(0)).delete("c");
+	(counts || g.NilMap(0)).delete("z");
+	var ok = (counts || g.NilMap(0)).get("c")[1];
+
+	if (!ok && (counts || g.NilMap(0)).len() === 2) {
+		console.log("[OK] delete\n");
+	} else {
+		alert("[Error] delete: " + ok + " " + (counts || g.NilMap(0)).len() + "\n");
+	}
+
+
+
+	var squares = g.NewMap(0, [[1, 1], [2, 4], [3, 9], [4, 16]]);
+	var n = 0;
+	for (var _e1 of (squares || g.NilMap(0)).f.values()) { var k = _e1[0];
+		for (var _e2 of (squares || g.NilMap(0)).f.values()) { var other = _e2[0];
+			if (other !== k) {
+				(squares || g.NilMap(0)).delete(other);
+			}
+		}
+		n++;
+	}
+
+	if (n === 1 && (squares || g.NilMap(0)).len() === 1) {
+		console.log("[OK] range\n");
+	} else {
+		alert("[Error] range: " + n + " " + (squares || g.NilMap(0)).len() + "\n");
+	}
+
+}
+
+function main() {
+	console.log("\n== basic\n");
+	basic();
+	console.log("\n== composite\n");
+	composite();
+	console.log("\n== operations\n");
+	operations();
+}
+//# sourceMappingURL=map_key.js.map
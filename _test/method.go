@@ -108,6 +108,74 @@ func withNamedType() {
 
 // * * *
 
+type Point struct{ x, y int }
+
+func (p Point) moved(dx int) Point {
+	p.x += dx
+	return p
+}
+
+func (p *Point) move(dx int) {
+	p.x += dx
+}
+
+func (p *Point) reset() {
+	*p = Point{}
+}
+
+func receiver() {
+	p := Point{1, 2}
+	moved := p.moved(10)
+	p.move(5)
+	ptr := &p
+	ptr.move(1)
+	// Checking
+	if p.x == 7 && moved.x == 11 && ptr.moved(1).x == 8 && (*ptr).x == 7 {
+		println("[OK] value and pointer")
+	} else {
+		fmt.Println("[Error] value and pointer:", p.x, moved.x, ptr.x)
+	}
+	//==
+
+	points := []Point{{1, 1}, {2, 2}}
+	points[1].move(3)
+	copied := *ptr
+	ptr.reset()
+	// Checking
+	if points[1].x == 5 && p.x == 0 && copied.x == 7 {
+		println("[OK] addressable")
+	} else {
+		fmt.Println("[Error] addressable:", points[1].x, p.x, copied.x)
+	}
+}
+
+func methodValue() {
+	p := Point{1, 2}
+	moved := p.moved
+	move := p.move
+	p.x = 10
+	move(1)
+	// Checking
+	if moved(1).x == 2 && p.x == 11 {
+		println("[OK] method value")
+	} else {
+		fmt.Println("[Error] method value:", moved(1).x, p.x)
+	}
+	//==
+
+	byValue := Point.moved
+	byPointer := (*Point).move
+	byPointer(&p, 4)
+	// Checking
+	if byValue(p, 1).x == 16 && p.x == 15 {
+		println("[OK] method expression")
+	} else {
+		fmt.Println("[Error] method expression:", byValue(p, 1).x, p.x)
+	}
+}
+
+// * * *
+
 func main() {
 	println("\n== noMethod")
 	noMethod()
@@ -115,4 +183,8 @@ func main() {
 	method()
 	println("\n== withNamedType")
 	withNamedType()
+	println("\n== receiver")
+	receiver()
+	println("\n== methodValue")
+	methodValue()
 }
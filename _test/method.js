@@ -4,7 +4,7 @@
 
 function Rectangle(width, height) {
 	this.width=width; this.height=height;
-}
+} Rectangle.prototype.clone = function() { var c = Object.create(Rectangle.prototype); c.width = this.width; c.height = this.height; return c; }; Rectangle.prototype.eq = function(y) { return this.width === y.width && this.height === y.height; }; var Rectangle$type = g.Type("main.Rectangle", function(x, y) { return x.eq(y); }, ["area() float64"]);
 
 function noMethod() {
 	var area = function(r) {
@@ -30,7 +30,7 @@ Rectangle.prototype.area = function() {
 
 function Circle(radius) {
 	this.radius=radius;
-}
+} Circle.prototype.clone = function() { var c = Object.create(Circle.prototype); c.radius = this.radius; return c; }; Circle.prototype.eq = function(y) { return this.radius === y.radius; }; var Circle$type = g.Type("main.Circle", function(x, y) { return x.eq(y); }, ["area() float64"]);
 
 Circle.prototype.area = function() {
 	return this.radius * this.radius * Math.PI;
@@ -60,21 +60,21 @@ function method() {
 
 
 
-function SliceOfints(t) { this.t=t; }
-function AgesByNames(t) { this.t=t; }
+var SliceOfints$type = g.Type("main.SliceOfints", g.Uncomparable, ["sum() int"], {sum: SliceOfints$sum});
+var AgesByNames$type = g.Type("main.AgesByNames", g.Uncomparable, ["older() string"], {older: AgesByNames$older});
 
-SliceOfints.prototype.sum = function() {
+function SliceOfints$sum(s) {
 	var sum = 0;
-	var value; for (_ in s) { value = s[_];
+	var value; for (var _ in s.f) { value = s.f[_];
 		sum += value;
 	}
 	return sum;
 }
 
-AgesByNames.prototype.older = function() {
+function AgesByNames$older(people) {
 	var a = 0;
 	var n = "";
-	var value; for (key in people) { value = people[key];
+	var value; for (var _e1 of (people || g.NilMap(0)).f.values()) { var key = _e1[0]; value = _e1[1];
 		if (value > a) {
 			a = value;
 			n = key;
@@ -84,25 +84,93 @@ AgesByNames.prototype.older = function() {
 }
 
 function withNamedType() {
-	var s = new SliceOfints(1, 2, 3, 4, 5);
-	var folks = new AgesByNames();
-	folks["Bob"] = 36,
-	folks["Mike"] = 44,
-	folks["Jane"] = 30,
-	folks["Popey"] = 100;
+	var s = g.NewSlice([1, 2, 3, 4, 5], 0);
+	var folks = g.NewMap(0, [
+		["Bob", 36],
+		["Mike", 44],
+		["Jane", 30],
+		["Popey", 100]
+	]);
 
 
-
-	if (s.sum() === 15) {
+	if (SliceOfints$sum(s) === 15) {
 		console.log("[OK] sum\n");
 	} else {
-		alert("[Error] The sum of ints in the slice s is: " + s.sum() + "\n");
+		alert("[Error] The sum of ints in the slice s is: " + SliceOfints$sum(s) + "\n");
 	}
 
-	if (folks.older() === "Popey") {
+	if (AgesByNames$older(folks) === "Popey") {
 		console.log("[OK] older\n");
 	} else {
-		alert("[Error] The older in the map folks is: " + folks.older() + "\n");
+		alert(g.Decode("[Error] The older in the map folks is: " + AgesByNames$older(folks) + "\n"));
+	}
+}
+
+
+
+function Point(x, y) { this.x=x; this.y=y; } Point.prototype.clone = function() { var c = Object.create(Point.prototype); c.x = this.x; c.y = this.y; return c; }; Point.prototype.eq = function(y) { return this.x === y.x && this.y === y.y; }; var Point$type = g.Type("main.Point", function(x, y) { return x.eq(y); }, ["moved(dx int) main.Point"]);
+
+Point.prototype.moved = function(dx) { var p = this.clone();
+	p.x += dx;
+	return p;
+}
+
+Point.prototype.move = function(dx) {
+	this.x += dx;
+}
+
+Point.prototype.reset = function() {
+	Object.assign(this, new Point(0, 0));
+}
+
+function receiver() {
+	var p = new Point(1, 2);
+	var moved = p.moved(10);
+	p.move(5);
+	var ptr = p;
+	ptr.move(1);
+
+	if (p.x === 7 && moved.x === 11 && ptr.moved(1).x === 8 && (ptr).x === 7) {
+		console.log("[OK] value and pointer\n");
+	} else {
+		alert("[Error] value and pointer: " + p.x + " " + moved.x + " " + ptr.x + "\n");
+	}
+
+
+	var points = g.NewSlice([new Point(1, 1), new Point(2, 2)], 0);
+	points.f[1].move(3);
+	var copied = ptr.clone();
+	ptr.reset();
+
+	if (points.f[1].x === 5 && p.x === 0 && copied.x === 7) {
+		console.log("[OK] addressable\n");
+	} else {
+		alert("[Error] addressable: " + points.f[1].x + " " + p.x + " " + copied.x + "\n");
+	}
+}
+
+function methodValue() {
+	var p = new Point(1, 2);
+	var moved = g.Bind(p.clone(), "moved");
+	var move = g.Bind(p, "move");
+	p.x = 10;
+	g.Func(move)(1);
+
+	if (g.Func(moved)(1).x === 2 && p.x === 11) {
+		console.log("[OK] method value\n");
+	} else {
+		alert("[Error] method value: " + g.Func(moved)(1).x + " " + p.x + "\n");
+	}
+
+
+	var byValue = g.MethodExpr("moved");
+	var byPointer = g.MethodExpr("move");
+	g.Func(byPointer)(p, 4);
+
+	if (g.Func(byValue)(p, 1).x === 16 && p.x === 15) {
+		console.log("[OK] method expression\n");
+	} else {
+		alert("[Error] method expression: " + g.Func(byValue)(p, 1).x + " " + p.x + "\n");
 	}
 }
 
@@ -115,4 +183,9 @@ function main() {
 	method();
 	console.log("\n== withNamedType\n");
 	withNamedType();
+	console.log("\n== receiver\n");
+	receiver();
+	console.log("\n== methodValue\n");
+	methodValue();
 }
+//# sourceMappingURL=method.js.map
@@ -0,0 +1,32 @@
+/* Generated by GoScript <github.com/kless/GoScript> */
+var multi = {}; (function() {
+
+
+
+
+function Circle(radius) {
+	this.radius=radius;
+} Circle.prototype.clone = function() { var c = Object.create(Circle.prototype); c.radius = this.radius; return c; }; Circle.prototype.eq = function(y) { return this.radius === y.radius; }; var Circle$type = g.Type("multi.Circle", function(x, y) { return x.eq(y); }, ["area() float64"]);
+
+Circle.prototype.area = function() {
+	return this.radius * this.radius * Math.PI;
+}
+
+function NewCircle(radius) {
+	return new Circle(radius);
+}
+
+
+const Version = "0.1";
+
+var circles = 0;
+
+function Total(radius) {
+	var c = NewCircle(radius);
+	circles++;
+	return c.area();
+}
+
+g.Export(multi, [Circle$type, Circle, NewCircle, Version, Total]);
+})();
+//# sourceMappingURL=multi.js.map
@@ -0,0 +1,17 @@
+package multi
+
+import "math"
+
+// Shapes spread over several files of the same package.
+
+type Circle struct {
+	radius float64
+}
+
+func (c Circle) area() float64 {
+	return c.radius * c.radius * math.Pi
+}
+
+func NewCircle(radius float64) Circle {
+	return Circle{radius}
+}
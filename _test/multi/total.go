@@ -0,0 +1,11 @@
+package multi
+
+const Version = "0.1"
+
+var circles = 0
+
+func Total(radius float64) float64 {
+	c := NewCircle(radius)
+	circles++
+	return c.area()
+}
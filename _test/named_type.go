@@ -0,0 +1,161 @@
+package main
+
+import "fmt"
+
+type Celsius float64
+
+func (c Celsius) String() string      { return fmt.Sprintf("%d C", int(c)) }
+func (c Celsius) Fahrenheit() Celsius { return c*9/5 + 32 }
+
+type Counter int
+
+func (c *Counter) Inc()    { *c++ }
+func (c Counter) Get() int { return int(c) }
+
+type IntList []int
+
+func (l IntList) Sum() int {
+	sum := 0
+	for _, v := range l {
+		sum += v
+	}
+	return sum
+}
+
+type Scores map[string]int
+
+func (s Scores) Best() string {
+	best, name := 0, ""
+	for k, v := range s {
+		if v > best {
+			best, name = v, k
+		}
+	}
+	return name
+}
+
+type Vec [3]int
+
+func (v Vec) Scaled(n int) Vec {
+	for i := range v {
+		v[i] *= n
+	}
+	return v
+}
+
+type Stringer interface {
+	String() string
+}
+
+type Getter interface {
+	Get() int
+}
+
+func basic() {
+	c := Celsius(100)
+	hot := c > 50
+	c += 5
+	// Checking
+	if c == 105 && hot && c.String() == "105 C" && Celsius(0).Fahrenheit() == 32 && float64(c)/2 == 52.5 {
+		println("[OK] basic")
+	} else {
+		fmt.Println("[Error] basic:", c, c.String(), Celsius(0).Fahrenheit())
+	}
+	//==
+
+	var n Counter
+	n.Inc()
+	n.Inc()
+	p := &n
+	p.Inc()
+	// Checking
+	if n == 3 && n.Get() == 3 && p.Get() == 3 {
+		println("[OK] pointer receiver")
+	} else {
+		fmt.Println("[Error] pointer receiver:", n.Get(), p.Get())
+	}
+}
+
+func composite() {
+	l := IntList{1, 2, 3}
+	l[0] = 10
+	made := make(IntList, 2)
+	// Checking
+	if l.Sum() == 15 && len(l) == 3 && IntList([]int{4, 5}).Sum() == 9 && len(made) == 2 {
+		println("[OK] slice")
+	} else {
+		fmt.Println("[Error] slice:", l.Sum(), len(l), len(made))
+	}
+	//==
+
+	s := Scores{"ann": 3, "bob": 5}
+	s["eve"] = 4
+	// Checking
+	if s.Best() == "bob" && len(s) == 3 && s["eve"] == 4 {
+		println("[OK] map")
+	} else {
+		fmt.Println("[Error] map:", s.Best(), len(s))
+	}
+	//==
+
+	v := Vec{1, 2}
+	w := v.Scaled(2)
+	// Checking
+	if v == (Vec{1, 2, 0}) && w[1] == 4 && w[2] == 0 {
+		println("[OK] array")
+	} else {
+		fmt.Println("[Error] array:", v[0], w[1])
+	}
+}
+
+func iface() {
+	var s Stringer = Celsius(20)
+	c := Celsius(30)
+	var any interface{} = c
+	_, isStringer := any.(Stringer)
+	// Checking
+	if s.String() == "20 C" && isStringer && any.(Celsius) == 30 && any == Celsius(30) {
+		println("[OK] interface")
+	} else {
+		fmt.Println("[Error] interface:", s.String(), isStringer)
+	}
+	//==
+
+	n := Counter(7)
+	var gt Getter = &n
+	n.Inc()
+	// Checking
+	if gt.Get() == 8 {
+		println("[OK] interface of pointer")
+	} else {
+		fmt.Println("[Error] interface of pointer:", gt.Get())
+	}
+}
+
+func methodValue() {
+	c := Celsius(10)
+	str := c.String
+	c = 20
+	f := Celsius.Fahrenheit
+	var n Counter
+	inc := n.Inc
+	inc()
+	get := (*Counter).Get
+	// Checking
+	if str() == "10 C" && f(100) == 212 && n == 1 && get(&n) == 1 {
+		println("[OK] method value, expression")
+	} else {
+		fmt.Println("[Error] method value, expression:", str(), f(100), n)
+	}
+}
+
+func main() {
+	println("\n== basic")
+	basic()
+	println("\n== composite")
+	composite()
+	println("\n== iface")
+	iface()
+	println("\n== methodValue")
+	methodValue()
+}
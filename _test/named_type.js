@@ -0,0 +1,162 @@
+/* Generated by GoScript <github.com/kless/GoScript> */
+
+
+
+var Celsius$type = g.Type("main.Celsius", undefined, ["Fahrenheit() main.Celsius", "String() string"], {Fahrenheit: Celsius$Fahrenheit, String: Celsius$String});
+
+function Celsius$String(c) { return "" + Math.trunc(c) + " C"; }
+function Celsius$Fahrenheit(c) { return c * 9 / 5 + 32; }
+
+var Counter$type = g.Type("main.Counter", undefined, ["Get() int"], {Get: Counter$Get});
+
+function Counter$Inc(c) { c.p++; }
+function Counter$Get(c) { return c; }
+
+var IntList$type = g.Type("main.IntList", g.Uncomparable, ["Sum() int"], {Sum: IntList$Sum});
+
+function IntList$Sum(l) {
+	var sum = 0;
+	var v; for (var _ in l.f) { v = l.f[_];
+		sum += v;
+	}
+	return sum;
+}
+
+var Scores$type = g.Type("main.Scores", g.Uncomparable, ["Best() string"], {Best: Scores$Best});
+
+function Scores$Best(s) {
+	var best = 0, name = "";
+	var v; for (var _e1 of (s || g.NilMap(0)).f.values()) { var k = _e1[0]; v = _e1[1];
+		if (v > best) {
+			best = v, name = k;
+		}
+	}
+	return name;
+}
+
+var Vec$type = g.Type("main.Vec", function(x, y) { return g.ArrayEq(x, y, function(a, b) { return a === b; }); }, ["Scaled(n int) main.Vec"], {Scaled: Vec$Scaled});
+
+function Vec$Scaled(v, n) { v = v.slice();
+	for (var i in v) {
+		v[i] *= n;
+	}
+	return v;
+}
+
+var Stringer$type = g.Iface("main.Stringer", ["String() string"]);
+
+
+
+var Getter$type = g.Iface("main.Getter", ["Get() int"]);
+
+
+
+function basic() {
+	var c = 100;
+	var hot = c > 50;
+	c += 5;
+
+	if (c === 105 && hot && Celsius$String(c) === "105 C" && Celsius$Fahrenheit(0) === 32 && c / 2 === 52.5) {
+		console.log("[OK] basic\n");
+	} else {
+		alert(g.Decode("[Error] basic: " + c + " " + Celsius$String(c) + " " + Celsius$Fahrenheit(0) + "\n"));
+	}
+
+
+	var n = {p:0};
+	Counter$Inc(n);
+	Counter$Inc(n);
+	var p = n;
+	Counter$Inc(p);
+
+	if (n.p === 3 && Counter$Get(n.p) === 3 && Counter$Get(p.p) === 3) {
+		console.log("[OK] pointer receiver\n");
+	} else {
+		alert("[Error] pointer receiver: " + Counter$Get(n.p) + " " + Counter$Get(p.p) + "\n");
+	}
+}
+
+function composite() {
+	var l = g.NewSlice([1, 2, 3], 0);
+	l.f[0] = 10;
+	var made = g.MakeSlice(0, 2);
+
+	if (IntList$Sum(l) === 15 && l.len === 3 && IntList$Sum(g.NewSlice([4, 5], 0)) === 9 && made.len === 2) {
+		console.log("[OK] slice\n");
+	} else {
+		alert("[Error] slice: " + IntList$Sum(l) + " " + l.len + " " + made.len + "\n");
+	}
+
+
+	var s = g.NewMap(0, [["ann", 3], ["bob", 5]]);
+	s.entry("eve")[1] = 4;
+
+	if (Scores$Best(s) === "bob" && (s || g.NilMap(0)).len() === 3 && (s || g.NilMap(0)).get("eve")[0] === 4) {
+		console.log("[OK] map\n");
+	} else {
+		alert(g.Decode("[Error] map: " + Scores$Best(s) + " " + (s || g.NilMap(0)).len() + "\n"));
+	}
+
+
+	var v = [1, 2, 0];
+	var w = Vec$Scaled(v, 2);
+
+	if (g.ArrayEq(v, ([1, 2, 0]), function(a, b) { return a === b; }) && w[1] === 4 && w[2] === 0) {
+		console.log("[OK] array\n");
+	} else {
+		alert("[Error] array: " + v[0] + " " + w[1] + "\n");
+	}
+}
+
+function iface() {
+	var s = g.Box(20, Celsius$type);
+	var c = 30;
+	var any = g.Box(c, Celsius$type);
+	var isStringer = g.AssertOk(any, Stringer$type, undefined)[1];
+
+	if (s.String() === "20 C" && isStringer && g.Assert(any, Celsius$type) === 30 && g.Equal(any, g.Box(30, Celsius$type))) {
+		console.log("[OK] interface\n");
+	} else {
+		alert(g.Decode("[Error] interface: " + s.String() + " " + isStringer + "\n"));
+	}
+
+
+	var n = {p:7};
+	var gt = g.Box(n, g.Type("*main.Counter", undefined, ["Get() int", "Inc()"], {Get: g.Deref(Counter$Get), Inc: Counter$Inc}));
+	Counter$Inc(n);
+
+	if (gt.Get() === 8) {
+		console.log("[OK] interface of pointer\n");
+	} else {
+		alert("[Error] interface of pointer: " + gt.Get() + "\n");
+	}
+}
+
+function methodValue() {
+	var c = 10;
+	var str = Celsius$String.bind(undefined, c);
+	c = 20;
+	var f = Celsius$Fahrenheit;
+	var n = {p:0};
+	var inc = Counter$Inc.bind(undefined, n);
+	g.Func(inc)();
+	var get = g.Deref(Counter$Get);
+
+	if (g.Func(str)() === "10 C" && g.Func(f)(100) === 212 && n.p === 1 && g.Func(get)(n) === 1) {
+		console.log("[OK] method value, expression\n");
+	} else {
+		alert(g.Decode("[Error] method value, expression: " + g.Func(str)() + " " + g.Func(f)(100) + " " + n.p + "\n"));
+	}
+}
+
+function main() {
+	console.log("\n== basic\n");
+	basic();
+	console.log("\n== composite\n");
+	composite();
+	console.log("\n== iface\n");
+	iface();
+	console.log("\n== methodValue\n");
+	methodValue();
+}
+//# sourceMappingURL=named_type.js.map
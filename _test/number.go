@@ -0,0 +1,189 @@
+package main
+
+import "fmt"
+
+func overflow() {
+	var u8 uint8 = 255
+	u8++
+	// Checking
+	if u8 == 0 {
+		println("[OK] uint8")
+	} else {
+		fmt.Println("[Error] uint8:", u8)
+	}
+	//==
+
+	var i8 int8 = 127
+	i8 += 2
+	// Checking
+	if i8 == -127 {
+		println("[OK] int8")
+	} else {
+		fmt.Println("[Error] int8:", i8)
+	}
+	//==
+
+	var u16 uint16 = 0
+	u16--
+	// Checking
+	if u16 == 65535 {
+		println("[OK] uint16")
+	} else {
+		fmt.Println("[Error] uint16:", u16)
+	}
+	//==
+
+	var i32 int32 = 1 << 30
+	i32 = i32 * 4
+	// Checking
+	if i32 == 0 {
+		println("[OK] int32")
+	} else {
+		fmt.Println("[Error] int32:", i32)
+	}
+	//==
+
+	var u32 uint32 = 0xFFFFFFFF
+	u32 = u32 * u32
+	// Checking
+	if u32 == 1 {
+		println("[OK] uint32")
+	} else {
+		fmt.Println("[Error] uint32:", u32)
+	}
+	//==
+
+	i8 = -128
+	i8 = -i8
+	// Checking
+	if i8 == -128 {
+		println("[OK] negation")
+	} else {
+		fmt.Println("[Error] negation:", i8)
+	}
+	//==
+}
+
+func division() {
+	a, b := 7, 2
+	// Checking
+	if a/b == 3 && -a/b == -3 && a%b == 1 && -a%b == -1 {
+		println("[OK] integer")
+	} else {
+		fmt.Println("[Error] integer:", a/b, -a/b, a%b, -a%b)
+	}
+	//==
+
+	x, y := 7.0, 2.0
+	// Checking
+	if x/y == 3.5 {
+		println("[OK] float")
+	} else {
+		fmt.Println("[Error] float:", x/y)
+	}
+	//==
+}
+
+func shift() {
+	var u32 uint32 = 1 << 31
+	n := uint(33)
+	// Checking
+	if u32>>1 == 1<<30 && u32<<1 == 0 && u32>>n == 0 && u32<<n == 0 {
+		println("[OK] unsigned")
+	} else {
+		fmt.Println("[Error] unsigned:", u32>>1, u32<<1, u32>>n, u32<<n)
+	}
+	//==
+
+	var i8 int8 = -8
+	// Checking
+	if i8>>1 == -4 && i8>>n == -1 && i8<<4 == -128 {
+		println("[OK] signed")
+	} else {
+		fmt.Println("[Error] signed:", i8>>1, i8>>n, i8<<4)
+	}
+	//==
+
+	var u uint = 1 << 40
+	i := 1 << 35
+	w, k := 3, uint(40)
+	// Checking
+	if u>>1 == 1<<39 && u<<2 == 1<<42 && w<<k == 3<<40 && -i>>33 == -4 && w>>k == 0 {
+		println("[OK] int, uint")
+	} else {
+		fmt.Println("[Error] int, uint:", u>>1, u<<2, w<<k, -i>>33, w>>k)
+	}
+	//==
+}
+
+func bitwise() {
+	i := 1 << 35
+	j := -1 << 40
+	var u uint = 1<<45 | 6
+	// Checking
+	if i|1 == 1<<35+1 && i&(i+3) == i && i^(i|2) == 2 && (i|5)&^i == 5 &&
+		j|i == -1<<40+1<<35 && j&-1 == j && ^i == -1<<35-1 && u&^6 == 1<<45 && u&7 == 6 {
+		println("[OK] int, uint")
+	} else {
+		fmt.Println("[Error] int, uint:", i|1, i&(i+3), i^(i|2), (i|5)&^i, j|i, ^i, u&^6)
+	}
+	//==
+
+	x := 1 << 33
+	x |= 1
+	x <<= 3
+	x &^= 8
+	// Checking
+	if x == 1<<36 {
+		println("[OK] assignment")
+	} else {
+		fmt.Println("[Error] assignment:", x)
+	}
+	//==
+}
+
+func conversion() {
+	f := 300.75
+	i := int(f)
+	j := 200
+	// Checking
+	if i == 300 && uint8(i) == 44 && int8(j) == -56 {
+		println("[OK] integer")
+	} else {
+		fmt.Println("[Error] integer:", i, uint8(i), int8(j))
+	}
+	//==
+
+	var f32 float32 = 0.1
+	// Checking
+	if float64(f32) != 0.1 && f32*3 == float32(0.3) && fmt.Sprint(f32) == "0.1" && fmt.Sprint(f32*3) == "0.3" {
+		println("[OK] float32")
+	} else {
+		fmt.Println("[Error] float32:", f32, f32*3)
+	}
+	//==
+
+	const p60 = 1 << 60
+	var u uint = 1 << 63
+	var f64 float64 = p60
+	// Checking
+	if u/2 == 1<<62 && f64/1024 == 1<<50 {
+		println("[OK] big constants")
+	} else {
+		fmt.Println("[Error] big constants:", u, f64)
+	}
+	//==
+}
+
+func main() {
+	println("\n== overflow")
+	overflow()
+	println("\n== division")
+	division()
+	println("\n== shift")
+	shift()
+	println("\n== bitwise")
+	bitwise()
+	println("\n== conversion")
+	conversion()
+}
@@ -0,0 +1,190 @@
+/* Generated by GoScript <github.com/kless/GoScript> */
+
+
+
+function overflow() {
+	var u8 = 255;
+	u8 = u8 + 1 & 255;
+
+	if (u8 === 0) {
+		console.log("[OK] uint8\n");
+	} else {
+		alert("[Error] uint8: " + u8 + "\n");
+	}
+
+
+	var i8 = 127;
+	i8 = i8 + 2 << 24 >> 24;
+
+	if (i8 === -127) {
+		console.log("[OK] int8\n");
+	} else {
+		alert("[Error] int8: " + i8 + "\n");
+	}
+
+
+	var u16 = 0;
+	u16 = u16 - 1 & 65535;
+
+	if (u16 === 65535) {
+		console.log("[OK] uint16\n");
+	} else {
+		alert("[Error] uint16: " + u16 + "\n");
+	}
+
+
+	var i32 = 1073741824;
+	i32 = Math.imul(i32, 4);
+
+	if (i32 === 0) {
+		console.log("[OK] int32\n");
+	} else {
+		alert("[Error] int32: " + i32 + "\n");
+	}
+
+
+	var u32 = 0xFFFFFFFF;
+	u32 = Math.imul(u32, u32) >>> 0;
+
+	if (u32 === 1) {
+		console.log("[OK] uint32\n");
+	} else {
+		alert("[Error] uint32: " + u32 + "\n");
+	}
+
+
+	i8 = -128;
+	i8 = -i8 << 24 >> 24;
+
+	if (i8 === -128) {
+		console.log("[OK] negation\n");
+	} else {
+		alert("[Error] negation: " + i8 + "\n");
+	}
+
+}
+
+function division() {
+	var a = 7, b = 2;
+
+	if (g.Quo(a, b) === 3 && g.Quo(-a, b) === -3 && g.Rem(a, b) === 1 && g.Rem(-a, b) === -1) {
+		console.log("[OK] integer\n");
+	} else {
+		alert("[Error] integer: " + g.Quo(a, b) + " " + g.Quo(-a, b) + " " + g.Rem(a, b) + " " + g.Rem(-a, b) + "\n");
+	}
+
+
+	var x = 7.0, y = 2.0;
+
+	if (x / y === 3.5) {
+		console.log("[OK] float\n");
+	} else {
+		alert("[Error] float: " + x / y + "\n");
+	}
+
+}
+
+function shift() {
+	var u32 = 2147483648;
+	var n = 33;
+
+	if (u32 >>> 1 === 1073741824 && (u32 << 1) >>> 0 === 0 && g.Shr(u32, n) === 0 && g.Shl(u32, n) >>> 0 === 0) {
+		console.log("[OK] unsigned\n");
+	} else {
+		alert("[Error] unsigned: " + (u32 >>> 1) + " " + ((u32 << 1) >>> 0) + " " + g.Shr(u32, n) + " " + (g.Shl(u32, n) >>> 0) + "\n");
+	}
+
+
+	var i8 = -8;
+
+	if (i8 >> 1 === -4 && g.Shr(i8, n) === -1 && (i8 << 4) << 24 >> 24 === -128) {
+		console.log("[OK] signed\n");
+	} else {
+		alert("[Error] signed: " + (i8 >> 1) + " " + g.Shr(i8, n) + " " + ((i8 << 4) << 24 >> 24) + "\n");
+	}
+
+
+	var u = 1099511627776;
+	var i = 34359738368;
+	var w = 3, k = 40;
+
+	if (Math.floor(u / 2) === 549755813888 && u * 4 === 4398046511104 && g.Shl(w, k) === 3298534883328 && Math.floor(-i / 8589934592) === -4 && g.Shr(w, k) === 0) {
+		console.log("[OK] int, uint\n");
+	} else {
+		alert("[Error] int, uint: " + Math.floor(u / 2) + " " + u * 4 + " " + g.Shl(w, k) + " " + Math.floor(-i / 8589934592) + " " + g.Shr(w, k) + "\n");
+	}
+
+}
+
+function bitwise() {
+	var i = 34359738368;
+	var j = -1099511627776;
+	var u = 35184372088838;
+
+	if (g.Or(i, 1) === 34359738369 && g.And(i, (i + 3)) === i && g.Xor(i, (g.Or(i, 2))) === 2 && g.AndNot((g.Or(i, 5)), i) === 5 && g.Or(j, i) === -1065151889408 && g.And(j, -1) === j && g.Xor(i, -1) === -34359738369 && g.AndNot(u, 6) === 35184372088832 && g.And(u, 7) === 6) {
+
+		console.log("[OK] int, uint\n");
+	} else {
+		alert("[Error] int, uint: " + g.Or(i, 1) + " " + g.And(i, (i + 3)) + " " + g.Xor(i, (g.Or(i, 2))) + " " + g.AndNot((g.Or(i, 5)), i) + " " + g.Or(j, i) + " " + g.Xor(i, -1) + " " + g.AndNot(u, 6) + "\n");
+	}
+
+
+	var x = 8589934592;
+	x = g.Or(x, 1);
+	x = x * 8;
+	x = g.AndNot(x, 8);
+
+	if (x === 68719476736) {
+		console.log("[OK] assignment\n");
+	} else {
+		alert("[Error] assignment: " + x + "\n");
+	}
+
+}
+
+function conversion() {
+	var f = 300.75;
+	var i = Math.trunc(f);
+	var j = 200;
+
+	if (i === 300 && (i & 255) === 44 && j << 24 >> 24 === -56) {
+		console.log("[OK] integer\n");
+	} else {
+		alert("[Error] integer: " + i + " " + (i & 255) + " " + (j << 24 >> 24) + "\n");
+	}
+
+
+	var f32 = 0.10000000149011612;
+
+	if (f32 !== 0.1 && Math.fround(f32 * 3) === 0.30000001192092896 && g.FormatFloat(f32, 32) === "0.1" && g.FormatFloat(Math.fround(f32 * 3), 32) === "0.3") {
+		console.log("[OK] float32\n");
+	} else {
+		alert("[Error] float32: " + g.FormatFloat(f32, 32) + " " + g.FormatFloat(Math.fround(f32 * 3), 32) + "\n");
+	}
+
+
+	const p60 = 1152921504606846976;
+	var u = 9223372036854775808;
+	var f64 = p60;
+
+	if (Math.trunc(u / 2) === 4611686018427387904 && f64 / 1024 === 1.125899906842624e+15) {
+		console.log("[OK] big constants\n");
+	} else {
+		alert("[Error] big constants: " + u + " " + f64 + "\n");
+	}
+
+}
+
+function main() {
+	console.log("\n== overflow\n");
+	overflow();
+	console.log("\n== division\n");
+	division();
+	console.log("\n== shift\n");
+	shift();
+	console.log("\n== bitwise\n");
+	bitwise();
+	console.log("\n== conversion\n");
+	conversion();
+}
+//# sourceMappingURL=number.js.map
@@ -215,6 +215,74 @@ func byReference_3() {
 	}
 }
 
+func double(n int) *int {
+	n *= 2
+	return &n
+}
+
+func divmod(a, b int) (q, r int) {
+	q, r = a/b, a%b
+	incr := func(p *int) { *p++ }
+	incr(&q)
+	return
+}
+
+func pair() (int, string) { return 1, "one" }
+
+func addressed() {
+	p := double(4)
+	q, r := divmod(7, 2)
+	n, name := pair()
+	np, namep := &n, &name
+	*np++
+	*namep += "!"
+	// Checking
+	if *p == 8 && q == 4 && r == 1 && n == 2 && name == "one!" {
+		println("[OK] parameter, result")
+	} else {
+		fmt.Println("[Error] parameter, result:", *p, q, r, n, name)
+	}
+	//==
+
+	var ptrs [3]*int
+	values := [3]int{1, 2, 3}
+	for i, v := range values {
+		ptrs[i] = &v
+	}
+	sum := 0
+	for _, p := range ptrs {
+		sum += *p
+	}
+	// Checking
+	if sum == 6 {
+		println("[OK] range")
+	} else {
+		fmt.Println("[Error] range:", sum)
+	}
+}
+
+type counter struct {
+	n    *int
+	name string
+}
+
+func indirection() {
+	total := 0
+	c := counter{&total, "total"}
+	add := func(i int) { *c.n += i }
+	add(2)
+	add(3)
+	p := c.n
+	pp := &p
+	**pp++
+	// Checking
+	if total == 6 && *c.n == 6 && c.n == &total {
+		println("[OK] field, pointer to pointer")
+	} else {
+		fmt.Println("[Error] field, pointer to pointer:", total, *c.n)
+	}
+}
+
 func main() {
 	println("\n== valueNil")
 	valueNil()
@@ -236,6 +304,10 @@ func main() {
 	byReference_2()
 	println("\n== byReference_3")
 	byReference_3()
+	println("\n== addressed")
+	addressed()
+	println("\n== indirection")
+	indirection()
 }
 
 /*
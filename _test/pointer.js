@@ -3,68 +3,68 @@
 
 
 
-var i = {p:undefined};
-var hello = {p:undefined};
-var p = {p:undefined};
+var i = {p:0};
+var hello = {p:""};
+var p = undefined;
 
 (function() {
 	p = i;
 	var helloPtr = hello;
-	console.log("helloPtr: " + helloPtr + "\n");
+	console.log(g.Decode("helloPtr: " + helloPtr + "\n"));
 }());
 
 function valueNil() {
 	var num = {p:10};
-	var p = {p:undefined};
+	var p = undefined;
 
 
 	var msg = "declaration";
-	if (p.p === undefined) {
-		console.log("[OK] " + msg + "\n");
+	if (p === undefined) {
+		console.log(g.Decode("[OK] " + msg + "\n"));
 	} else {
-		alert("[Error] " + msg + "\n");
+		alert(g.Decode("[Error] " + msg + "\n"));
 	}
 
 
 	p = num;
 
 	msg = "assignment";
-	if (p.p !== undefined) {
-		console.log("[OK] " + msg + "\n");
+	if (p !== undefined) {
+		console.log(g.Decode("[OK] " + msg + "\n"));
 	} else {
-		alert("[Error] " + msg + "\n");
+		alert(g.Decode("[Error] " + msg + "\n"));
 	}
 
 }
 
 function declaration() {
-	var i = {p:undefined};
-	var hello = {p:undefined};
-	var p = {p:undefined};
+	var i = {p:0};
+	var hello = {p:""};
+	var p = undefined;
 
 	p = i;
 	var helloPtr = hello;
-	console.log("p:  " + p + " " + "\nhelloPtr: " + helloPtr + "\n");
+	console.log(g.Decode("p:  " + p + " " + "\nhelloPtr: " + helloPtr + "\n"));
 }
 
 function showAddress() {
 	
 	var i = {p:9};
 	var hello = {p:"Hello world"};
-	var pi = {p:3.14};
+	var pi = {p:3.140000104904175};
 	var b = {p:true};
 
 
-	console.log("Hexadecimal address of 'i' is: " + i + "\n");
-	console.log("Hexadecimal address of 'hello' is: " + hello + "\n");
-	console.log("Hexadecimal address of 'pi' is: " + pi + "\n");
-	console.log("Hexadecimal address of 'b' is: " + b + "\n");
+	console.log(g.Decode("Hexadecimal address of 'i' is: " + i + "\n"));
+	console.log(g.Decode("Hexadecimal address of 'hello' is: " + hello + "\n"));
+	console.log(g.Decode("Hexadecimal address of 'pi' is: " + pi + "\n"));
+	console.log(g.Decode("Hexadecimal address of 'b' is: " + b + "\n"));
 }
 
 function access_1() {
 	var hello = {p:"Hello, mina-san!"};
 
-	var helloPtr = {p:undefined};
+	var helloPtr = undefined;
 	helloPtr = hello;
 
 	var i = {p:6};
@@ -74,14 +74,14 @@ function access_1() {
 	if (hello.p === "Hello, mina-san!" && helloPtr.p === "Hello, mina-san!") {
 		console.log("[OK] string\n");
 	} else {
-		alert("[Error] The string \"hello\" is: " + hello + "\n");
-		alert("\tThe string pointed to by \"helloPtr\" is: " + helloPtr.p + "\n");
+		alert(g.Decode("[Error] The string \"hello\" is: " + hello.p + "\n"));
+		alert(g.Decode("\tThe string pointed to by \"helloPtr\" is: " + helloPtr.p + "\n"));
 	}
 
 	if (i.p === 6 && iPtr.p === 6) {
 		console.log("[OK] int\n");
 	} else {
-		alert("[Error] The value of \"i\" is: " + i + "\n");
+		alert("[Error] The value of \"i\" is: " + i.p + "\n");
 		alert("\tThe value pointed to by \"iPtr\" is: " + iPtr.p + "\n");
 	}
 }
@@ -95,7 +95,7 @@ function access_2() {
 	if (x.p === 4) {
 		console.log("[OK]\n");
 	} else {
-		alert("[Error] x is: " + x + "\n");
+		alert("[Error] x is: " + x.p + "\n");
 	}
 
 
@@ -104,18 +104,18 @@ function access_2() {
 	if (x.p === 5) {
 		console.log("[OK]\n");
 	} else {
-		alert("[Error] x is: " + x + "\n");
+		alert("[Error] x is: " + x.p + "\n");
 	}
 }
 
 function allocation() {
 	var sum = 0;
-	var doubleSum = {p:undefined};
+	var doubleSum = undefined;
 	for (var i = 0; i < 10; i++) {
 		sum += i;
 	}
 
-	doubleSum.p = 0;
+	doubleSum = {p:0};
 	doubleSum.p = sum * 2;
 
 
@@ -160,7 +160,7 @@ function byReference_1() {
 		console.log("[OK]\n");
 	} else {
 		alert("[Error] x+1 = " + x1 + "\n");
-		alert("\tx = " + x + "\n");
+		alert("\tx = " + x.p + "\n");
 	}
 
 
@@ -170,7 +170,7 @@ function byReference_1() {
 		console.log("[OK]\n");
 	} else {
 		alert("[Error] x+1 = " + x1 + "\n");
-		alert("\tx = " + x + "\n");
+		alert("\tx = " + x.p + "\n");
 	}
 
 }
@@ -186,7 +186,7 @@ function byReference_2() {
 	if (value.p === 7) {
 		console.log("[OK]\n");
 	} else {
-		alert("[Error] value = " + value + "\n");
+		alert("[Error] value = " + value.p + "\n");
 	}
 
 
@@ -195,7 +195,7 @@ function byReference_2() {
 	if (value.p === 8) {
 		console.log("[OK]\n");
 	} else {
-		alert("[Error] value = " + value + "\n");
+		alert("[Error] value = " + value.p + "\n");
 	}
 
 }
@@ -215,6 +215,74 @@ function byReference_3() {
 	}
 }
 
+function double(n) { n = {p:n};
+	n.p *= 2;
+	return n;
+}
+
+function divmod(a, b) { var q = {p:0}, r = 0;
+	q.p = g.Quo(a, b), r = g.Rem(a, b);
+	var incr = function(p) { p.p++; };
+	incr(q);
+	return [q.p, r];
+}
+
+function pair() { return [1, "one"]; }
+
+function addressed() {
+	var p = double(4);
+	var _ = divmod(7, 2), q = _[0], r = _[1];
+	var _ = pair(), n = {p:_[0]}, name = {p:_[1]};
+	var np = n, namep = name;
+	np.p++;
+	namep.p += "!";
+
+	if (p.p === 8 && q === 4 && r === 1 && n.p === 2 && name.p === "one!") {
+		console.log("[OK] parameter, result\n");
+	} else {
+		alert(g.Decode("[Error] parameter, result: " + p.p + " " + q + " " + r + " " + n.p + " " + name.p + "\n"));
+	}
+
+
+	var ptrs = []; for (var i=0; i<3; i++){ ptrs[i]=undefined; }
+	var values = [1, 2, 3];
+	var v; for (var i in values) { v = {p:values[i]};
+		ptrs[i] = v;
+	}
+	var sum = 0;
+	var p; for (var _ in ptrs) { p = ptrs[_];
+		sum += p.p;
+	}
+
+	if (sum === 6) {
+		console.log("[OK] range\n");
+	} else {
+		alert("[Error] range: " + sum + "\n");
+	}
+}
+
+function counter(n, name) {
+	this.n=n;
+	this.name=name;
+} counter.prototype.clone = function() { var c = Object.create(counter.prototype); c.n = this.n; c.name = this.name; return c; }; counter.prototype.eq = function(y) { return this.n === y.n && this.name === y.name; }; var counter$type = g.Type("main.counter", function(x, y) { return x.eq(y); });
+
+function indirection() {
+	var total = {p:0};
+	var c = new counter(total, "total");
+	var add = function(i) { c.n.p += i; };
+	add(2);
+	add(3);
+	var p = {p:c.n};
+	var pp = p;
+	pp.p.p++;
+
+	if (total.p === 6 && c.n.p === 6 && c.n === total) {
+		console.log("[OK] field, pointer to pointer\n");
+	} else {
+		alert("[Error] field, pointer to pointer: " + total.p + " " + c.n.p + "\n");
+	}
+}
+
 function main() {
 	console.log("\n== valueNil\n");
 	valueNil();
@@ -236,4 +304,9 @@ function main() {
 	byReference_2();
 	console.log("\n== byReference_3\n");
 	byReference_3();
+	console.log("\n== addressed\n");
+	addressed();
+	console.log("\n== indirection\n");
+	indirection();
 }
+//# sourceMappingURL=pointer.js.map
@@ -8,30 +8,30 @@ function valueNil() {
 
 	var msg = "value";
 	if (s.isNil()) {
-		console.log("[OK] " + msg + "\n");
+		console.log(g.Decode("[OK] " + msg + "\n"));
 	} else {
-		alert("[Error] " + msg + "\n");
+		alert(g.Decode("[Error] " + msg + "\n"));
 	}
 
 	msg = "length";
 	if (s.len === 0) {
-		console.log("[OK] " + msg + "\n");
+		console.log(g.Decode("[OK] " + msg + "\n"));
 	} else {
-		alert("[Error] " + msg + "\n");
+		alert(g.Decode("[Error] " + msg + "\n"));
 	}
 
 	msg = "capacity";
 	if (s.cap === 0) {
-		console.log("[OK] " + msg + "\n");
+		console.log(g.Decode("[OK] " + msg + "\n"));
 	} else {
-		alert("[Error] " + msg + "\n");
+		alert(g.Decode("[Error] " + msg + "\n"));
 	}
 
 }
 
 function shortHand() {
 
-	var array = []; for (var i=0; i<10; i++){ array[i]=0; } array = ['a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j'];
+	var array = [97, 98, 99, 100, 101, 102, 103, 104, 105, 106];
 
 	var a_slice = new g.S([], 0, 0), b_slice = new g.S([], 0, 0);
 
@@ -39,16 +39,16 @@ function shortHand() {
 
 	a_slice.set(array, 4, 8);
 
-	if (a_slice.toString() === "efgh" && a_slice.len === 4 && a_slice.cap === 6) {
-		console.log("[OK] " + msg + "\n");
+	if (g.BytesToString(a_slice) === "efgh" && a_slice.len === 4 && a_slice.cap === 6) {
+		console.log(g.Decode("[OK] " + msg + "\n"));
 	} else {
-		alert("[Error] " + msg + "\n");
+		alert(g.Decode("[Error] " + msg + "\n"));
 	}
 
 
 	a_slice.set(array, 6, 7);
 
-	if (a_slice.toString() === "g") {
+	if (g.BytesToString(a_slice) === "g") {
 		console.log("[OK]\n");
 	} else {
 		alert("[Error]\n");
@@ -59,16 +59,16 @@ function shortHand() {
 
 	a_slice.set(array, 0, 3);
 
-	if (a_slice.toString() === "abc" && a_slice.len === 3 && a_slice.cap === 10) {
-		console.log("[OK] " + msg + "\n");
+	if (g.BytesToString(a_slice) === "abc" && a_slice.len === 3 && a_slice.cap === 10) {
+		console.log(g.Decode("[OK] " + msg + "\n"));
 	} else {
-		alert("[Error] " + msg + "\n");
+		alert(g.Decode("[Error] " + msg + "\n"));
 	}
 
 
 	a_slice.set(array, 5);
 
-	if (a_slice.toString() === "fghij") {
+	if (g.BytesToString(a_slice) === "fghij") {
 		console.log("[OK]\n");
 	} else {
 		alert("[Error]\n");
@@ -77,7 +77,7 @@ function shortHand() {
 
 	a_slice.set(array, 0);
 
-	if (a_slice.toString() === "abcdefghij") {
+	if (g.BytesToString(a_slice) === "abcdefghij") {
 		console.log("[OK]\n");
 	} else {
 		alert("[Error]\n");
@@ -88,16 +88,16 @@ function shortHand() {
 
 	a_slice.set(array, 3, 7);
 
-	if (a_slice.toString() === "defg" && a_slice.len === 4 && a_slice.cap === 7) {
-		console.log("[OK] " + msg + "\n");
+	if (g.BytesToString(a_slice) === "defg" && a_slice.len === 4 && a_slice.cap === 7) {
+		console.log(g.Decode("[OK] " + msg + "\n"));
 	} else {
-		alert("[Error] " + msg + "\n");
+		alert(g.Decode("[Error] " + msg + "\n"));
 	}
 
 
 	b_slice.set(a_slice, 1, 3);
 
-	if (b_slice.toString() === "ef" && b_slice.len === 2 && b_slice.cap === 6) {
+	if (g.BytesToString(b_slice) === "ef" && b_slice.len === 2 && b_slice.cap === 6) {
 		console.log("[OK]\n");
 	} else {
 		alert("[Error]\n");
@@ -106,7 +106,7 @@ function shortHand() {
 
 	b_slice.set(a_slice, 0, 3);
 
-	if (b_slice.toString() === "def") {
+	if (g.BytesToString(b_slice) === "def") {
 		console.log("[OK]\n");
 	} else {
 		alert("[Error]\n");
@@ -115,7 +115,7 @@ function shortHand() {
 
 	b_slice.set(a_slice, 0);
 
-	if (b_slice.toString() === "defg") {
+	if (g.BytesToString(b_slice) === "defg") {
 		console.log("[OK]\n");
 	} else {
 		alert("[Error]\n");
@@ -127,10 +127,10 @@ function shortHand() {
 
 
 function Max(slice) {
-	var max = slice[0];
-	for (var index = 1; index < slice.length; index++) {
-		if (slice[index] > max) {
-			max = slice[index];
+	var max = slice.f[0];
+	for (var index = 1; index < slice.len; index++) {
+		if (slice.f[index] > max) {
+			max = slice.f[index];
 		}
 	}
 	return max;
@@ -138,16 +138,16 @@ function Max(slice) {
 
 function useFunc() {
 
-	var A1 = []; for (var i=0; i<10; i++){ A1[i]=0; } A1 = [1, 2, 3, 4, 5, 6, 7, 8, 9];
-	var A2 = []; for (var i=0; i<4; i++){ A2[i]=0; } A2 = [1, 2, 3, 4];
-	var A3 = []; for (var i=0; i<1; i++){ A3[i]=0; } A3 = [1];
+	var A1 = [1, 2, 3, 4, 5, 6, 7, 8, 9, 0];
+	var A2 = [1, 2, 3, 4];
+	var A3 = [1];
 
 
 	var slice = new g.S([], 0, 0);
 
 	slice.set(A1, 0);
 
-	if (Max(slice.f) === 9) {
+	if (Max(slice) === 9) {
 		console.log("[OK] A1\n");
 	} else {
 		alert("[Error] A1\n");
@@ -156,7 +156,7 @@ function useFunc() {
 
 	slice.set(A2, 0);
 
-	if (Max(slice.f) === 4) {
+	if (Max(slice) === 4) {
 		console.log("[OK] A2\n");
 	} else {
 		alert("[Error] A2\n");
@@ -165,7 +165,7 @@ function useFunc() {
 
 	slice.set(A3, 0);
 
-	if (Max(slice.f) === 1) {
+	if (Max(slice) === 1) {
 		console.log("[OK] A3\n");
 	} else {
 		alert("[Error] A3\n");
@@ -182,12 +182,12 @@ function PrintByteSlice(name, slice) {
 	}
 	s += "" + slice.f[slice.len - 1] + "]";
 
-	console.log(s + "\n");
+	console.log(g.Decode(s + "\n"));
 }
 
 function reference() {
 
-	var A = []; for (var i=0; i<10; i++){ A[i]=0; } A = ['a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j'];
+	var A = [97, 98, 99, 100, 101, 102, 103, 104, 105, 106];
 
 
 	var slice1 = g.NewSlice(A, 3, 7);
@@ -202,7 +202,7 @@ function reference() {
 	PrintByteSlice("slice3", slice3);
 
 
-	A[4] = 'E';
+	A[4] = 69;
 	console.log("\n=== Content of A and the slices, after changing 'e' to 'E' in array A\n");
 	PrintByteSlice("A", g.NewSlice(A, 0));
 	PrintByteSlice("slice1", slice1);
@@ -210,7 +210,7 @@ function reference() {
 	PrintByteSlice("slice3", slice3);
 
 
-	slice2[1] = 'G';
+	slice2.f[1] = 71;
 	console.log("\n=== Content of A and the slices, after changing 'g' to 'G' in slice2\n");
 	PrintByteSlice("A", g.NewSlice(A, 0));
 	PrintByteSlice("slice1", slice1);
@@ -269,3 +269,4 @@ function main() {
 	console.log("\n== resize\n");
 	resize();
 }
+//# sourceMappingURL=slice.js.map
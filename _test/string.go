@@ -0,0 +1,105 @@
+package main
+
+import "fmt"
+
+const hello = "¡Hola, 世界!"
+
+func bytes() {
+	s := hello
+	i, j := 1, 3
+	// Checking
+	if len(s) == 15 && s[0] == 0xC2 && s[1] == 0xA1 && s[2] == 'H' {
+		println("[OK] length and index")
+	} else {
+		fmt.Println("[Error] length and index:", len(s), s[0], s[1], s[2])
+	}
+	//==
+
+	// Checking
+	if s[i:j] == "\xa1H" && s[j:] == "ola, 世界!" && s[:i+1] == "¡" && s[8:11] == "世" {
+		println("[OK] slice")
+	} else {
+		fmt.Println("[Error] slice:", s[i:j], s[j:], s[:i+1], s[8:11])
+	}
+	//==
+
+	r := 'a'
+	r += 2
+	// Checking
+	if r == 99 && 'a' == 97 && '世' == 0x4E16 && s[5]-'a' == 0 {
+		println("[OK] rune literal")
+	} else {
+		fmt.Println("[Error] rune literal:", r, s[5]-'a')
+	}
+	//==
+}
+
+func ranges() {
+	offsets, runes := "", ""
+	for i, r := range hello {
+		offsets += fmt.Sprint(i) + " "
+		runes += string(r)
+	}
+	// Checking
+	if offsets == "0 2 3 4 5 6 7 8 11 14 " && runes == hello {
+		println("[OK] index and rune")
+	} else {
+		fmt.Println("[Error] index and rune:", offsets, runes)
+	}
+	//==
+
+	n := 0
+	for _, r := range "a\xffb\xe4\xb8" {
+		if r == 0xFFFD {
+			n++
+		}
+	}
+	// Checking
+	if n == 3 {
+		println("[OK] invalid encoding")
+	} else {
+		fmt.Println("[Error] invalid encoding:", n)
+	}
+	//==
+}
+
+func conversion() {
+	var r rune = 0x4E16
+	n := -1
+	// Checking
+	if string(r) == "世" && string(n) == "�" && string(rune(0x1F600)) == "😀" &&
+		string('x') == "x" {
+		println("[OK] string(rune)")
+	} else {
+		fmt.Println("[Error] string(rune):", string(r), string(n))
+	}
+	//==
+
+	b := []byte(hello)
+	runes := []rune(hello)
+	// Checking
+	if len(b) == 15 && b[0] == 0xC2 && len(runes) == 10 && runes[0] == '¡' &&
+		runes[7] == '世' {
+		println("[OK] []byte and []rune")
+	} else {
+		fmt.Println("[Error] []byte and []rune:", len(b), len(runes))
+	}
+	//==
+
+	// Checking
+	if string(b) == hello && string(runes) == hello && string(runes[7:9]) == "世界" {
+		println("[OK] string([]byte) and string([]rune)")
+	} else {
+		fmt.Println("[Error] string([]byte) and string([]rune):", string(b), string(runes))
+	}
+	//==
+}
+
+func main() {
+	println("\n== bytes")
+	bytes()
+	println("\n== ranges")
+	ranges()
+	println("\n== conversion")
+	conversion()
+}
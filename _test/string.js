@@ -0,0 +1,106 @@
+/* Generated by GoScript <github.com/kless/GoScript> */
+
+
+
+const hello = "\xc2\xa1Hola, \xe4\xb8\x96\xe7\x95\x8c!";
+
+function bytes() {
+	var s = hello;
+	var i = 1, j = 3;
+
+	if (s.length === 15 && s.charCodeAt(0) === 0xC2 && s.charCodeAt(1) === 0xA1 && s.charCodeAt(2) === 72) {
+		console.log("[OK] length and index\n");
+	} else {
+		alert("[Error] length and index: " + s.length + " " + s.charCodeAt(0) + " " + s.charCodeAt(1) + " " + s.charCodeAt(2) + "\n");
+	}
+
+
+
+	if (s.slice(i, j) === "\xa1H" && s.slice(j) === "ola, \xe4\xb8\x96\xe7\x95\x8c!" && s.slice(0, i + 1) === "\xc2\xa1" && s.slice(8, 11) === "\xe4\xb8\x96") {
+		console.log("[OK] slice\n");
+	} else {
+		alert(g.Decode("[Error] slice: " + s.slice(i, j) + " " + s.slice(j) + " " + s.slice(0, i + 1) + " " + s.slice(8, 11) + "\n"));
+	}
+
+
+	var r = 97;
+	r = r + 2 | 0;
+
+	if (r === 99 && true && true && (s.charCodeAt(5) - 97 & 255) === 0) {
+		console.log("[OK] rune literal\n");
+	} else {
+		alert("[Error] rune literal: " + r + " " + (s.charCodeAt(5) - 97 & 255) + "\n");
+	}
+
+}
+
+function ranges() {
+	var offsets = "", runes = "";
+	var r; for (var i = 0, _r1; i < hello.length; i += _r1[1]) { _r1 = g.DecodeRune(hello, i); r = _r1[0];
+		offsets += i + " ";
+		runes += g.EncodeRune(r);
+	}
+
+	if (offsets === "0 2 3 4 5 6 7 8 11 14 " && runes === hello) {
+		console.log("[OK] index and rune\n");
+	} else {
+		alert(g.Decode("[Error] index and rune: " + offsets + " " + runes + "\n"));
+	}
+
+
+	var n = 0;
+	var r; for (var _s1 = "a\xffb\xe4\xb8", _i1 = 0, _r1; _i1 < _s1.length; _i1 += _r1[1]) { _r1 = g.DecodeRune(_s1, _i1); r = _r1[0];
+		if (r === 0xFFFD) {
+			n++;
+		}
+	}
+
+	if (n === 3) {
+		console.log("[OK] invalid encoding\n");
+	} else {
+		alert("[Error] invalid encoding: " + n + "\n");
+	}
+
+}
+
+function conversion() {
+	var r = 0x4E16;
+	var n = -1;
+
+	if (g.EncodeRune(r) === "\xe4\xb8\x96" && g.EncodeRune(n) === "\xef\xbf\xbd" && true && true) {
+
+		console.log("[OK] string(rune)\n");
+	} else {
+		alert(g.Decode("[Error] string(rune): " + g.EncodeRune(r) + " " + g.EncodeRune(n) + "\n"));
+	}
+
+
+	var b = g.StringToBytes(hello);
+	var runes = g.StringToRunes(hello);
+
+	if (b.len === 15 && b.f[0] === 0xC2 && runes.len === 10 && runes.f[0] === 161 && runes.f[7] === 19990) {
+
+		console.log("[OK] []byte and []rune\n");
+	} else {
+		alert("[Error] []byte and []rune: " + b.len + " " + runes.len + "\n");
+	}
+
+
+
+	if (g.BytesToString(b) === hello && g.RunesToString(runes) === hello && g.RunesToString(g.NewSlice(runes, 7, 9)) === "\xe4\xb8\x96\xe7\x95\x8c") {
+		console.log("[OK] string([]byte) and string([]rune)\n");
+	} else {
+		alert(g.Decode("[Error] string([]byte) and string([]rune): " + g.BytesToString(b) + " " + g.RunesToString(runes) + "\n"));
+	}
+
+}
+
+function main() {
+	console.log("\n== bytes\n");
+	bytes();
+	console.log("\n== ranges\n");
+	ranges();
+	console.log("\n== conversion\n");
+	conversion();
+}
+//# sourceMappingURL=string.js.map
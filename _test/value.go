@@ -0,0 +1,180 @@
+package main
+
+import "fmt"
+
+type point struct{ x, y int }
+
+type rect struct {
+	min, max point
+	corners  [2]point
+	name     *string
+}
+
+type box struct{ a [2]int }
+
+func (r rect) grow(n int) int {
+	r.max.x += n
+	r.max.y += n
+	return (r.max.x - r.min.x) * (r.max.y - r.min.y)
+}
+
+func (p point) sum() int { return p.x + p.y }
+
+func move(p point, dx int) point {
+	p.x += dx
+	return p
+}
+
+func same(p point) point { return p }
+
+func sumArray(a [3]int) int { return a[0] + a[1] + a[2] }
+
+func withPointer(p point) (point, *point) {
+	ptr := &p
+	return p, ptr
+}
+
+func assignment() {
+	a := point{1, 2}
+	b := a
+	b.x = 10
+	// Checking
+	if a.x == 1 && b.x == 10 {
+		println("[OK] struct")
+	} else {
+		fmt.Println("[Error] struct:", a.x, b.x)
+	}
+	//==
+
+	arr := [3]int{1, 2, 3}
+	copied := arr
+	copied[0] = 100
+	// Checking
+	if arr[0] == 1 && copied[0] == 100 {
+		println("[OK] array")
+	} else {
+		fmt.Println("[Error] array:", arr[0], copied[0])
+	}
+	//==
+
+	name := "box"
+	corners := [2]point{point{0, 0}, point{2, 2}}
+	r := rect{point{0, 0}, point{2, 2}, corners, &name}
+	s := r
+	s.min.x = -1
+	s.corners[1].y = 5
+	// Checking
+	if r.min.x == 0 && r.corners[1].y == 2 && s.corners[1].y == 5 && s.name == r.name {
+		println("[OK] nested")
+	} else {
+		fmt.Println("[Error] nested:", r.min.x, r.corners[1].y, s.name == r.name)
+	}
+	//==
+}
+
+func passing() {
+	p := point{1, 2}
+	q := move(p, 5)
+	// Checking
+	if p.x == 1 && q.x == 6 && p.sum() == 3 {
+		println("[OK] argument")
+	} else {
+		fmt.Println("[Error] argument:", p.x, q.x)
+	}
+	//==
+
+	r := rect{max: point{1, 1}}
+	area := r.grow(1)
+	// Checking
+	if area == 4 && r.max.x == 1 {
+		println("[OK] receiver")
+	} else {
+		fmt.Println("[Error] receiver:", area, r.max.x)
+	}
+	//==
+
+	first := func(q point) int {
+		get := func() int { return q.x }
+		p.x = 50
+		return get()
+	}
+	// Checking
+	if first(p) == 1 && p.x == 50 {
+		println("[OK] closure")
+	} else {
+		fmt.Println("[Error] closure:", first(p))
+	}
+	//==
+
+	a := point{1, 2}
+	b := same(a)
+	b.x = 8
+	c, ptr := withPointer(a)
+	ptr.x = 9
+	// Checking
+	if a.x == 1 && b.x == 8 && c.x == 1 && ptr.x == 9 {
+		println("[OK] result")
+	} else {
+		fmt.Println("[Error] result:", a.x, b.x, c.x, ptr.x)
+	}
+	//==
+}
+
+func elements() {
+	points := []point{point{1, 1}, point{2, 2}}
+	for _, p := range points {
+		p.x = 0
+	}
+	// Checking
+	if points[0].x == 1 && points[1].x == 2 {
+		println("[OK] range")
+	} else {
+		fmt.Println("[Error] range:", points[0].x, points[1].x)
+	}
+	//==
+
+	p := point{3, 4}
+	r := rect{min: p}
+	list := []point{p}
+	p.x = 9
+	// Checking
+	if r.min.x == 3 && list[0].x == 3 {
+		println("[OK] composite literal")
+	} else {
+		fmt.Println("[Error] composite literal:", r.min.x, list[0].x)
+	}
+	//==
+
+	m := map[string]point{"k": {1, 2}}
+	q := m["k"]
+	q.x = 7
+	v, ok := m["k"]
+	v.x = 5
+	// Checking
+	if m["k"].x == 1 && q.x == 7 && v.x == 5 && ok {
+		println("[OK] map")
+	} else {
+		fmt.Println("[Error] map:", m["k"].x, q.x, v.x, ok)
+	}
+	//==
+
+	bx := box{[2]int{1}}
+	r2 := rect{corners: [2]point{{1, 2}}}
+	n := sumArray([3]int{4, 5})
+	// Checking
+	if bx.a[0] == 1 && bx.a[1] == 0 && r2.corners[0].y == 2 && r2.corners[1].x == 0 && n == 9 {
+		println("[OK] array literal")
+	} else {
+		fmt.Println("[Error] array literal:", bx.a, r2.corners[0].y, n)
+	}
+	//==
+}
+
+func main() {
+	println("\n== assignment")
+	assignment()
+	println("\n== passing")
+	passing()
+	println("\n== elements")
+	elements()
+}
@@ -0,0 +1,181 @@
+/* Generated by GoScript <github.com/kless/GoScript> */
+
+
+
+function point(x, y) { this.x=x; this.y=y; } point.prototype.clone = function() { var c = Object.create(point.prototype); c.x = this.x; c.y = this.y; return c; }; point.prototype.eq = function(y) { return this.x === y.x && this.y === y.y; }; var point$type = g.Type("main.point", function(x, y) { return x.eq(y); }, ["sum() int"]);
+
+function rect(min, max, corners, name) {
+	this.min=min; this.max=max;
+	this.corners=corners;
+	this.name=name;
+} rect.prototype.clone = function() { var c = Object.create(rect.prototype); c.min = this.min.clone(); c.max = this.max.clone(); c.corners = this.corners.map(function(v) { return v.clone(); }); c.name = this.name; return c; }; rect.prototype.eq = function(y) { return this.min.eq(y.min) && this.max.eq(y.max) && g.ArrayEq(this.corners, y.corners, function(a, b) { return a.eq(b); }) && this.name === y.name; }; var rect$type = g.Type("main.rect", function(x, y) { return x.eq(y); }, ["grow(n int) int"]);
+
+function box(a) { this.a=a; } box.prototype.clone = function() { var c = Object.create(box.prototype); c.a = this.a.slice(); return c; }; box.prototype.eq = function(y) { return g.ArrayEq(this.a, y.a, function(a, b) { return a === b; }); }; var box$type = g.Type("main.box", function(x, y) { return x.eq(y); });
+
+rect.prototype.grow = function(n) { var r = this.clone();
+	r.max.x += n;
+	r.max.y += n;
+	return (r.max.x - r.min.x) * (r.max.y - r.min.y);
+}
+
+point.prototype.sum = function() { return this.x + this.y; }
+
+function move(p, dx) { p = p.clone();
+	p.x += dx;
+	return p;
+}
+
+function same(p) { return p.clone(); }
+
+function sumArray(a) { return a[0] + a[1] + a[2]; }
+
+function withPointer(p) { p = p.clone();
+	var ptr = p;
+	return [p.clone(), ptr];
+}
+
+function assignment() {
+	var a = new point(1, 2);
+	var b = a.clone();
+	b.x = 10;
+
+	if (a.x === 1 && b.x === 10) {
+		console.log("[OK] struct\n");
+	} else {
+		alert("[Error] struct: " + a.x + " " + b.x + "\n");
+	}
+
+
+	var arr = [1, 2, 3];
+	var copied = arr.slice();
+	copied[0] = 100;
+
+	if (arr[0] === 1 && copied[0] === 100) {
+		console.log("[OK] array\n");
+	} else {
+		alert("[Error] array: " + arr[0] + " " + copied[0] + "\n");
+	}
+
+
+	var name = {p:"box"};
+	var corners = [new point(0, 0), new point(2, 2)];
+	var r = new rect(new point(0, 0), new point(2, 2), corners.map(function(v) { return v.clone(); }), name);
+	var s = r.clone();
+	s.min.x = -1;
+	s.corners[1].y = 5;
+
+	if (r.min.x === 0 && r.corners[1].y === 2 && s.corners[1].y === 5 && s.name === r.name) {
+		console.log("[OK] nested\n");
+	} else {
+		alert("[Error] nested: " + r.min.x + " " + r.corners[1].y + " " + (s.name === r.name) + "\n");
+	}
+
+}
+
+function passing() {
+	var p = new point(1, 2);
+	var q = move(p, 5);
+
+	if (p.x === 1 && q.x === 6 && p.sum() === 3) {
+		console.log("[OK] argument\n");
+	} else {
+		alert("[Error] argument: " + p.x + " " + q.x + "\n");
+	}
+
+
+	var r = new rect(new point(0, 0), new point(0, 0), g.MakeArray(2, function() { return new point(0, 0); }), undefined); r.max = new point(1, 1);
+	var area = r.grow(1);
+
+	if (area === 4 && r.max.x === 1) {
+		console.log("[OK] receiver\n");
+	} else {
+		alert("[Error] receiver: " + area + " " + r.max.x + "\n");
+	}
+
+
+	var first = function(q) { q = q.clone();
+		var get = function() { return q.x; };
+		p.x = 50;
+		return get();
+	};
+
+	if (first(p) === 1 && p.x === 50) {
+		console.log("[OK] closure\n");
+	} else {
+		alert("[Error] closure: " + first(p) + "\n");
+	}
+
+
+	var a = new point(1, 2);
+	var b = same(a);
+	b.x = 8;
+	var _ = withPointer(a), c = _[0], ptr = _[1];
+	ptr.x = 9;
+
+	if (a.x === 1 && b.x === 8 && c.x === 1 && ptr.x === 9) {
+		console.log("[OK] result\n");
+	} else {
+		alert("[Error] result: " + a.x + " " + b.x + " " + c.x + " " + ptr.x + "\n");
+	}
+
+}
+
+function elements() {
+	var points = g.NewSlice([new point(1, 1), new point(2, 2)], 0);
+	var p; for (var _ in points.f) { p = points.f[_].clone();
+		p.x = 0;
+	}
+
+	if (points.f[0].x === 1 && points.f[1].x === 2) {
+		console.log("[OK] range\n");
+	} else {
+		alert("[Error] range: " + points.f[0].x + " " + points.f[1].x + "\n");
+	}
+
+
+	var p = new point(3, 4);
+	var r = new rect(new point(0, 0), new point(0, 0), g.MakeArray(2, function() { return new point(0, 0); }), undefined); r.min = p.clone();
+	var list = g.NewSlice([p.clone()], 0);
+	p.x = 9;
+
+	if (r.min.x === 3 && list.f[0].x === 3) {
+		console.log("[OK] composite literal\n");
+	} else {
+		alert("[Error] composite literal: " + r.min.x + " " + list.f[0].x + "\n");
+	}
+
+
+	var m = g.NewMap(new point(0, 0), [["k", new point(1, 2)]]);
+	var q = (m || g.NilMap(new point(0, 0))).get("k")[0].clone();
+	q.x = 7;
+	var _ = (m || g.NilMap(new point(0, 0))).get("k"), v = _[0].clone(), ok = _[1];
+	v.x = 5;
+
+	if ((m || g.NilMap(new point(0, 0))).get("k")[0].x === 1 && q.x === 7 && v.x === 5 && ok) {
+		console.log("[OK] map\n");
+	} else {
+		alert("[Error] map: " + (m || g.NilMap(new point(0, 0))).get("k")[0].x + " " + q.x + " " + v.x + " " + ok + "\n");
+	}
+
+
+	var bx = new box([1, 0]);
+	var r2 = new rect(new point(0, 0), new point(0, 0), g.MakeArray(2, function() { return new point(0, 0); }), undefined); r2.corners = [new point(1, 2), new point(0, 0)];
+	var n = sumArray([4, 5, 0]);
+
+	if (bx.a[0] === 1 && bx.a[1] === 0 && r2.corners[0].y === 2 && r2.corners[1].x === 0 && n === 9) {
+		console.log("[OK] array literal\n");
+	} else {
+		alert(g.Decode("[Error] array literal: " + bx.a + " " + r2.corners[0].y + " " + n + "\n"));
+	}
+
+}
+
+function main() {
+	console.log("\n== assignment\n");
+	assignment();
+	console.log("\n== passing\n");
+	passing();
+	console.log("\n== elements\n");
+	elements();
+}
+//# sourceMappingURL=value.js.map
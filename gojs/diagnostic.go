@@ -0,0 +1,150 @@
+// Copyright 2012  The "GoScript" Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gojs
+
+import (
+	"fmt"
+	"go/ast"
+	"go/scanner"
+	"go/token"
+	"strings"
+)
+
+// Severity indicates how serious is a diagnostic.
+type Severity uint8
+
+const (
+	Error Severity = iota // the code is not written
+	Warning
+)
+
+func (s Severity) String() string {
+	if s == Warning {
+		return "warning"
+	}
+	return "error"
+}
+
+// Diagnostic represents a problem found in the Go source code.
+type Diagnostic struct {
+	Pos      token.Position // file, line and column; or invalid
+	Severity Severity
+	Code     string // stable identifier, like "unsupported-defer"
+	Msg      string
+}
+
+// Returns the diagnostic formatted like "file:line:column: message".
+func (d Diagnostic) String() string {
+	if d.Pos.IsValid() {
+		return d.Pos.String() + ": " + d.Msg
+	}
+	return d.Msg
+}
+
+// Diagnostics represents the errors and warnings of a compilation: first the
+// errors and then the warnings, each in the order in which they have been
+// found.
+type Diagnostics []Diagnostic
+
+// Returns the diagnostics of severity "s".
+func (list Diagnostics) filter(s Severity) Diagnostics {
+	res := make(Diagnostics, 0)
+
+	for _, d := range list {
+		if d.Severity == s {
+			res = append(res, d)
+		}
+	}
+	return res
+}
+
+// Errors returns the diagnostics of severity Error.
+func (list Diagnostics) Errors() Diagnostics { return list.filter(Error) }
+
+// Warnings returns the diagnostics of severity Warning.
+func (list Diagnostics) Warnings() Diagnostics { return list.filter(Warning) }
+
+// HasErrors reports whether there is some diagnostic of severity Error.
+func (list Diagnostics) HasErrors() bool {
+	for _, d := range list {
+		if d.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
+
+// Error returns the errors, one by line, so that the diagnostics can be
+// returned like an error.
+func (list Diagnostics) Error() string {
+	errs := list.Errors()
+	lines := make([]string, len(errs))
+
+	for i, d := range errs {
+		lines[i] = d.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Returns the diagnostics for the errors got at parsing.
+func syntaxDiagnostics(err error) (Diagnostics, bool) {
+	errList, ok := err.(scanner.ErrorList)
+	if !ok {
+		return nil, false
+	}
+
+	list := make(Diagnostics, len(errList))
+	for i, e := range errList {
+		list[i] = Diagnostic{e.Pos, Error, "syntax", e.Msg}
+	}
+	return list, true
+}
+
+// Returns the kind of the node, like "IndexExpr", to report it.
+func nodeKind(node ast.Node) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", node), "*ast.")
+}
+
+// Appends an error.
+func (tr *transform) addError(pos token.Pos, code, format string, a ...interface{}) {
+	if !tr.hasError {
+		tr.hasError = true
+	}
+	if len(tr.err) == tr.opts.MaxMessage {
+		return
+	}
+
+	tr.err = append(tr.err, Diagnostic{
+		tr.fset.Position(pos), Error, code, fmt.Sprintf(format, a...),
+	})
+}
+
+// Appends a warning message.
+func (tr *transform) addWarning(pos token.Pos, code, format string, a ...interface{}) {
+	if len(tr.warn) == tr.opts.MaxMessage {
+		return
+	}
+
+	tr.warn = append(tr.warn, Diagnostic{
+		tr.fset.Position(pos), Warning, code, fmt.Sprintf(format, a...),
+	})
+}
+
+// Returns the errors and then the warnings found.
+func (tr *transform) diagnostics() Diagnostics {
+	list := make(Diagnostics, 0, len(tr.err)+len(tr.warn))
+	list = append(list, tr.err...)
+	return append(list, tr.warn...)
+}
@@ -0,0 +1,124 @@
+// Copyright 2012  The "GoScript" Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gojs
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+)
+
+/*
+## Embedded fields
+
+An embedded field is a field named like its type, without the package; an
+embedded pointer stores the struct which it points to, or "undefined". The
+promoted fields and methods are selected through the embedded fields, like
+the checker resolves them:
+
+	type circle struct {  =>  function circle(point, radius) { this.point=point; this.radius=radius; }
+		point
+		radius int
+	}
+	c.x                   =>  c.point.x
+	c.sum()               =>  c.point.sum()
+
+The ambiguous selectors are reported by the checker. Each struct has the
+promoted methods too, which call the ones of the embedded field, so they are
+found by the boxes of the interfaces:
+
+	circle.prototype.sum = function() { return this.point.sum.apply(this.point, arguments); };
+*/
+
+// Returns the name of the embedded field of type "typ", at its position; or
+// nil if its kind is not supported, like a generic instantiation.
+func embeddedName(typ ast.Expr) *ast.Ident {
+	switch t := typ.(type) {
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr: // type of other package
+		return &ast.Ident{NamePos: typ.Pos(), Name: t.Sel.Name}
+	case *ast.Ident:
+		return &ast.Ident{NamePos: typ.Pos(), Name: t.Name}
+	}
+	return nil
+}
+
+// Returns the embedded fields through which the selector reaches a promoted
+// field or method, like ".point"; or an empty string if it is not promoted.
+func (tr *transform) embeddedPath(sel *ast.SelectorExpr) string {
+	if tr.info == nil {
+		return ""
+	}
+	s, ok := tr.info.Selections[sel]
+	if !ok {
+		return ""
+	}
+	return embeddedPathOf(s.Recv(), s.Index())
+}
+
+// Returns the embedded fields of the path of indexes "index", from a value of
+// type "typ"; the last index is the field or method selected.
+func embeddedPathOf(typ types.Type, index []int) string {
+	path := ""
+
+	for _, i := range index[:len(index)-1] {
+		if p, ok := typ.Underlying().(*types.Pointer); ok {
+			typ = p.Elem()
+		}
+		f := typ.Underlying().(*types.Struct).Field(i)
+		path += "." + f.Name()
+		typ = f.Type()
+	}
+	return path
+}
+
+// Returns the promoted methods of the struct declared with the name "name",
+// which call the methods of its embedded fields; or an empty string if its
+// type is unknown or it has not any.
+func (tr *transform) promotedMethods(name *ast.Ident) string {
+	if tr.info == nil {
+		return ""
+	}
+	obj, ok := tr.info.Defs[name]
+	if !ok || obj == nil {
+		return ""
+	}
+
+	// The methods with a receiver pointer are promoted too, since all of them
+	// are in the prototype.
+	mset := types.NewMethodSet(types.NewPointer(obj.Type()))
+	methods := ""
+
+	for i := 0; i < mset.Len(); i++ {
+		m := mset.At(i)
+		if len(m.Index()) == 1 {
+			continue
+		}
+		field := "this" + embeddedPathOf(obj.Type(), m.Index())
+
+		if methods != "" {
+			methods += SP
+		}
+		if fun := tr.staticMethod(m.Obj()); fun != "" {
+			methods += fmt.Sprintf("%s.prototype.%s%s=%sfunction()%s{%sreturn g.CallFunc(%s,%s,%sarguments);%s};",
+				name.Name, m.Obj().Name(), SP, SP, SP, SP, fun, SP+field, SP, SP)
+			continue
+		}
+		methods += fmt.Sprintf("%s.prototype.%s%s=%sfunction()%s{%sreturn %s.%s.apply(%s,%sarguments);%s};",
+			name.Name, m.Obj().Name(), SP, SP, SP, SP, field, m.Obj().Name(), field, SP, SP)
+	}
+	return methods
+}
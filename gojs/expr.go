@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
 	"strings"
 )
 
@@ -36,13 +37,14 @@ type expression struct {
 
 	//isFunc     bool // anonymous function
 	isAddress  bool
+	isCallee   bool // is it the function called?
 	isEllipsis bool
 	isIdent    bool
 	isMake     bool
 	isNil      bool
-	isPointer  bool
 	isSlice    bool
-	isValue    bool // is it on the right of the assignment?
+	isTarget   bool // is it assigned?
+	inMap      bool // is it the list of elements of a map?
 
 	arrayHasElts  bool // does array has elements?
 	skipSemicolon bool
@@ -52,6 +54,8 @@ type expression struct {
 	isBasicLit     bool
 	returnBasicLit bool
 
+	prec int // precedence of the JavaScript operator at the top; or 0
+
 	lenArray []string // the lengths of an array
 	index    []string
 }
@@ -90,6 +94,8 @@ func (tr *transform) newExpression(iVar interface{}) *expression {
 		false,
 		false,
 		false,
+		false,
+		0,
 		make([]string, 0),
 		make([]string, 0),
 	}
@@ -103,6 +109,15 @@ func (tr *transform) getExpression(expr ast.Expr) *expression {
 	return e
 }
 
+// Returns the Go expression which is assigned, transformed to JavaScript.
+func (tr *transform) getTarget(expr ast.Expr) *expression {
+	e := tr.newExpression(nil)
+	e.isTarget = true
+
+	e.transform(expr)
+	return e
+}
+
 // Transforms the Go expression.
 func (e *expression) transform(expr ast.Expr) {
 	switch typ := expr.(type) {
@@ -147,7 +162,10 @@ func (e *expression) transform(expr ast.Expr) {
 				e.WriteString(strings.Repeat("}", len(e.lenArray)-1))
 			}
 		default:
-			panic(fmt.Sprintf("*expression.transform: type unimplemented: %T", t))
+			e.tr.addError(t.Pos(), "unsupported-array",
+				"array of elements of kind %s", nodeKind(t))
+			e.hasError = true
+			return
 		}
 		e.skipSemicolon = true
 
@@ -155,6 +173,12 @@ func (e *expression) transform(expr ast.Expr) {
 	//  Kind     token.Token // token.INT, token.FLOAT, token.IMAG, token.CHAR, or token.STRING
 	//  Value    string      // literal string
 	case *ast.BasicLit:
+		// The constant is rounded to float32, or built like an object.
+		// The strings are written by bytes, and the runes like numbers.
+		if b := numberOf(e.tr.typeOf(typ)); (b != nil && (b.Kind() == types.Float32 || isObject(b)) ||
+			typ.Kind == token.STRING || typ.Kind == token.CHAR) && e.writeConst(typ) {
+			break
+		}
 		e.WriteString(typ.Value)
 		e.isBasicLit = true
 
@@ -185,23 +209,41 @@ func (e *expression) transform(expr ast.Expr) {
 			e.transform(typ.Y)
 			break
 		}
+		if e.writeConst(typ) {
+			break
+		}
 
 		// * * *
 		x := e.tr.getExpression(typ.X)
 		y := e.tr.getExpression(typ.Y)
 
+		if isObject(numberOf(e.tr.typeOf(typ.X))) {
+			if js, prec, ok := compareMethod(typ.Op, x, y); ok {
+				e.WriteString(js)
+				e.prec = prec
+				break
+			}
+		}
+		if !isComparing {
+			js, prec := e.tr.binaryOp(typ.Op, e.tr.typeOf(typ), x, y, typ.Y)
+			e.WriteString(js)
+			e.prec = prec
+			break
+		}
+		prec := jsPrecedence[op]
+
 		if isComparing {
 			xStr := stripField(x.String())
 			yStr := stripField(y.String())
 
-			if y.isNil && e.tr.isType(sliceType, xStr) {
+			if y.isNil && e.tr.isType(sliceType, typ.X, xStr) {
 				if isOpNot {
 					e.WriteString("!")
 				}
 				e.WriteString(xStr + ".isNil()")
 				break
 			}
-			if x.isNil && e.tr.isType(sliceType, yStr) {
+			if x.isNil && e.tr.isType(sliceType, typ.Y, yStr) {
 				if isOpNot {
 					e.WriteString("!")
 				}
@@ -210,44 +252,111 @@ func (e *expression) transform(expr ast.Expr) {
 			}
 		}
 
+		// The values are compared according to their type.
+		if isComparing && !x.isNil && !y.isNil {
+			xType, yType := e.tr.typeOf(typ.X), e.tr.typeOf(typ.Y)
+
+			if xType != nil && yType != nil {
+				js, prec := e.tr.equalOp(typ.Op, xType, yType, x, y)
+				e.WriteString(js)
+				e.prec = prec
+				break
+			}
+		}
+
 		// * * *
 		stringify := false
 
-		// JavaScript only compares basic literals.
-		if isComparing && !x.isBasicLit && !x.returnBasicLit && !y.isBasicLit && !y.returnBasicLit {
-			stringify = true
+		// JavaScript only compares basic values; the rest by identity.
+		if isComparing {
+			xType, yType := e.tr.typeOf(typ.X), e.tr.typeOf(typ.Y)
+
+			if xType != nil && yType != nil {
+				stringify = isComposite(xType) && isComposite(yType)
+			} else if !x.isBasicLit && !x.returnBasicLit && !y.isBasicLit && !y.returnBasicLit {
+				stringify = true
+			}
 		}
 
 		if stringify {
 			e.WriteString("JSON.stringify(" + x.String() + ")")
 		} else {
-			e.WriteString(x.String())
-		}
-		// To know when a pointer is compared with the value nil.
-		if y.isNil && !x.isPointer && !x.isAddress {
-			e.WriteString(NIL)
+			e.WriteString(paren(x.String(), x.prec, prec-1))
 		}
-
 		e.WriteString(SP + op + SP)
 
 		if stringify {
 			e.WriteString("JSON.stringify(" + y.String() + ")")
 		} else {
-			e.WriteString(y.String())
-		}
-		if x.isNil && !y.isPointer && !y.isAddress {
-			e.WriteString(NIL)
+			e.WriteString(paren(y.String(), y.prec, prec))
 		}
+		e.prec = prec
 
 	// godoc go/ast CallExpr
 	//  Fun      Expr      // function expression
 	//  Args     []Expr    // function arguments; or nil
 	case *ast.CallExpr:
+		// === Conversion to an interface
+		if to := e.tr.typeOf(typ.Fun); to != nil && e.tr.info.Types[typ.Fun].IsType() &&
+			types.IsInterface(to) {
+			x := e.tr.getExpression(typ.Args[0])
+			if !e.tr.needsBox(to, typ.Args[0]) {
+				e.prec = x.prec
+			}
+			e.WriteString(e.tr.valueTo(to, typ.Args[0], x.String(), x.prec))
+			break
+		}
+
+		// === Conversion to a function type, or to a named type which is not
+		// basic nor a struct; the value is not changed.
+		if to := e.tr.typeOf(typ.Fun); to != nil && e.tr.info.Types[typ.Fun].IsType() {
+			_, isNamed := to.(*types.Named)
+
+			switch to.Underlying().(type) {
+			case *types.Signature:
+				isNamed = true
+			case *types.Basic, *types.Struct:
+				isNamed = false
+			}
+			if isNamed {
+				x := e.tr.getExpression(typ.Args[0])
+				e.WriteString(x.String())
+				e.prec = x.prec
+				break
+			}
+		}
+
+		// === Value of a function type, which panics if it is nil
+		if e.tr.isFuncValue(typ.Fun) {
+			fun := e.tr.getExpression(typ.Fun)
+			if fun.hasError {
+				e.hasError = true
+				return
+			}
+			e.WriteString(fmt.Sprintf("g.Func(%s)(%s)", fun, e.tr.joinArgs(typ)))
+			break
+		}
+
+		// === Method of a named type which is not a struct
+		if e.writeStaticCall(typ) {
+			break
+		}
+
+		// The conversion to a named basic type is like the one to its
+		// underlying type, although the type is of other package.
+		conv := ""
+		if e.tr.info != nil && e.tr.info.Types[typ.Fun].IsType() {
+			if b, ok := e.tr.typeOf(typ.Fun).Underlying().(*types.Basic); ok {
+				conv = b.Name()
+			}
+		}
+
 		// === Library
-		if call, ok := typ.Fun.(*ast.SelectorExpr); ok {
+		if call, ok := typ.Fun.(*ast.SelectorExpr); ok && conv == "" {
+			e.isCallee = true
 			e.transform(call)
 
-			str := fmt.Sprintf("%s", e.tr.GetArgs(e.funcName, typ.Args))
+			str := fmt.Sprintf("%s", e.tr.GetArgs(e.funcName, typ))
 			if e.funcName != "fmt.Sprintf" && e.funcName != "fmt.Sprint" {
 				str = "(" + str + ")"
 			}
@@ -256,18 +365,39 @@ func (e *expression) transform(expr ast.Expr) {
 			break
 		}
 
-		// === Conversion: []byte()
+		// === Conversion: []byte(), []rune()
 		if call, ok := typ.Fun.(*ast.ArrayType); ok {
-			if call.Elt.(*ast.Ident).Name == "byte" {
+			kind := byteSliceOf(e.tr.typeOf(typ))
+
+			switch {
+			case kind == types.Invalid:
+				e.tr.addError(call.Pos(), "unsupported-conversion",
+					"conversion to slice other than []byte or []rune")
+				e.hasError = true
+			case !isString(e.tr.typeOf(typ.Args[0])):
 				e.transform(typ.Args[0])
-			} else {
-				panic(fmt.Sprintf("call of conversion unimplemented: []%T()", call))
+			case kind == types.Uint8:
+				e.WriteString(fmt.Sprintf("g.StringToBytes(%s)", e.tr.getExpression(typ.Args[0])))
+			default:
+				e.WriteString(fmt.Sprintf("g.StringToRunes(%s)", e.tr.getExpression(typ.Args[0])))
 			}
 			break
 		}
 
 		// === Built-in functions - golang.org/pkg/builtin/
-		call := typ.Fun.(*ast.Ident).Name
+		call := conv
+		if ident, ok := typ.Fun.(*ast.Ident); ok && call == "" {
+			call = ident.Name
+		} else if call == "" {
+			fun := e.tr.getExpression(typ.Fun)
+			if fun.hasError {
+				e.hasError = true
+				return
+			}
+			// A function literal called at once
+			e.WriteString(fmt.Sprintf("(%s)(%s)", fun, e.tr.joinArgs(typ)))
+			break
+		}
 
 		switch call {
 		case "make":
@@ -294,13 +424,30 @@ func (e *expression) transform(expr ast.Expr) {
 
 			case *ast.MapType:
 				e.tr.maps[e.tr.funcId][e.tr.blockId][e.tr.lastVarName] = void
-				e.WriteString("new g.M({}," + SP + e.tr.zeroOfMap(argType) + ")")
+				e.WriteString(e.tr.newMap(e.tr.typeOf(argType), ""))
 
 			case *ast.ChanType:
 				e.transform(typ.Fun)
 
 			default:
-				panic(fmt.Sprintf("call of 'make' unimplemented: %T", argType))
+				if t := e.tr.typeOf(argType); t != nil && dataTypeOf(t) == mapType {
+					e.tr.maps[e.tr.funcId][e.tr.blockId][e.tr.lastVarName] = void
+					e.WriteString(e.tr.newMap(t, ""))
+					break
+				} else if t != nil && dataTypeOf(t) == sliceType {
+					zero, _ := e.tr.zeroOf(true, t.Underlying().(*types.Slice).Elem())
+
+					e.WriteString(fmt.Sprintf("%s,%s%s", zero, SP,
+						e.tr.getExpression(typ.Args[1]))) // length
+					if len(typ.Args) == 3 {
+						e.WriteString("," + SP + e.tr.getExpression(typ.Args[2]).String())
+					}
+					e.isMake = true
+					break
+				}
+				e.tr.addError(argType.Pos(), "unsupported-make",
+					"built-in function make() of kind %s", nodeKind(argType))
+				e.hasError = true
 			}
 
 		case "new":
@@ -312,40 +459,84 @@ func (e *expression) transform(expr ast.Expr) {
 
 			case *ast.Ident:
 				value, _ := e.tr.zeroValue(true, argType)
+
+				// The structs are objects, which are their address.
+				if t := e.tr.typeOf(argType); t != nil && !isComposite(t) {
+					value = "{p:" + value + "}"
+				}
 				e.WriteString(value)
 
 			default:
-				panic(fmt.Sprintf("call of 'new' unimplemented: %T", argType))
+				e.tr.addError(argType.Pos(), "unsupported-new",
+					"built-in function new() of kind %s", nodeKind(argType))
+				e.hasError = true
 			}
 
 		// == Conversion
 		case "string":
+			if e.writeConst(typ) {
+				break
+			}
 			arg := e.tr.getExpression(typ.Args[0]).String()
 			_arg := stripField(arg)
+			from := e.tr.typeOf(typ.Args[0])
 
-			if !e.tr.isType(sliceType, _arg) {
+			if b := numberOf(from); b != nil {
+				if is64(b) {
+					arg = arg + ".toNumber()"
+				}
+				e.WriteString("g.EncodeRune(" + arg + ")")
+			} else if kind := byteSliceOf(from); kind == types.Uint8 {
+				e.WriteString("g.BytesToString(" + arg + ")")
+			} else if kind == types.Int32 {
+				e.WriteString("g.RunesToString(" + arg + ")")
+			} else if isString(from) {
+				e.WriteString(arg)
+			} else if !e.tr.isType(sliceType, typ.Args[0], _arg) {
 				e.WriteString(arg)
 				e.returnBasicLit = true
 			} else {
 				e.WriteString(_arg + ".toString()")
 			}
 
-		case "uint", "uint8", "uint16", "uint32",
-			"int", "int8", "int16", "int32",
-			"float32", "float64", "byte", "rune":
-			e.transform(typ.Args[0])
+		case "bool":
+			if e.writeConst(typ) {
+				break
+			}
+			x := e.tr.getExpression(typ.Args[0])
+			e.WriteString(x.String())
+			e.prec = x.prec
+
+		case "uint", "uint8", "uint16", "uint32", "uint64",
+			"int", "int8", "int16", "int32", "int64",
+			"float32", "float64", "complex64", "complex128", "byte", "rune":
+			if e.writeConst(typ) {
+				break
+			}
+			x := e.tr.getExpression(typ.Args[0])
+			js, prec := convert(numberOf(e.tr.typeOf(typ)), numberOf(e.tr.typeOf(typ.Args[0])),
+				x.String(), x.prec)
+
+			e.WriteString(js)
+			e.prec = prec
 			e.returnBasicLit = true
 		// ==
 
 		case "print", "println":
-			e.WriteString(fmt.Sprintf("console.log(%s)", e.tr.GetArgs(call, typ.Args)))
+			print := "console.log"
+			if e.tr.opts.Target == Node {
+				print = "process.stdout.write"
+			}
+			e.WriteString(fmt.Sprintf("%s(%s)", print, e.tr.GetArgs(call, typ)))
 
 		case "len":
 			arg := e.tr.getExpression(typ.Args[0]).String()
 			_arg := stripField(arg)
 
-			if e.tr.isType(sliceType, _arg) {
+			if e.tr.isType(sliceType, typ.Args[0], _arg) {
 				e.WriteString(_arg + ".len")
+			} else if e.tr.isType(mapType, typ.Args[0], _arg) {
+				e.WriteString(e.tr.readMap(typ.Args[0], _arg, 0) + ".len()")
 			} else {
 				e.WriteString(arg + ".length")
 			}
@@ -356,7 +547,7 @@ func (e *expression) transform(expr ast.Expr) {
 			arg := e.tr.getExpression(typ.Args[0]).String()
 			_arg := stripField(arg)
 
-			if e.tr.isType(sliceType, _arg) {
+			if e.tr.isType(sliceType, typ.Args[0], _arg) {
 				if strings.HasSuffix(arg, ".f") {
 					arg = _arg
 				}
@@ -366,42 +557,56 @@ func (e *expression) transform(expr ast.Expr) {
 			e.returnBasicLit = true
 
 		case "delete":
-			e.WriteString(fmt.Sprintf("delete %s.f[%s]",
-				e.tr.getExpression(typ.Args[0]).String(),
-				e.tr.getExpression(typ.Args[1]).String()))
+			m := e.tr.getExpression(typ.Args[0])
+			e.WriteString(fmt.Sprintf("%s.delete(%s)", e.tr.readMap(typ.Args[0], paren(m.String(), m.prec, 11), 0),
+				e.tr.getExpression(typ.Args[1])))
+
+		// == Complex numbers
+		case "complex":
+			if e.writeConst(typ) {
+				break
+			}
+			e.WriteString(fmt.Sprintf("new g.Complex(%s,%s)",
+				e.tr.getExpression(typ.Args[0]), SP+e.tr.getExpression(typ.Args[1]).String()))
+
+		case "real", "imag":
+			if e.writeConst(typ) {
+				break
+			}
+			part := ".re"
+			if call == "imag" {
+				part = ".im"
+			}
+			x := e.tr.getExpression(typ.Args[0])
+			e.WriteString(paren(x.String(), x.prec, 11) + part)
+		// ==
 
 		case "panic":
 			e.WriteString(fmt.Sprintf("throw new Error(%s)",
 				e.tr.getExpression(typ.Args[0])))
 
 		// === Not supported
-		case "recover", "complex":
-			e.tr.addError("%s: built-in function %s()",
-				e.tr.fset.Position(typ.Fun.Pos()), call)
-			e.tr.hasError = true
-			return
-		case "int64", "uint64":
-			e.tr.addError("%s: conversion of type %s",
-				e.tr.fset.Position(typ.Fun.Pos()), call)
+		case "recover":
+			e.tr.addError(typ.Fun.Pos(), "unsupported-"+call,
+				"built-in function %s()", call)
 			e.tr.hasError = true
 			return
 
 		// === Not implemented
-		case "append", "close", "copy", "uintptr":
-			panic(fmt.Sprintf("built-in call unimplemented: %s", call))
+		case "append", "close", "copy":
+			e.tr.addError(typ.Fun.Pos(), "unsupported-"+call,
+				"built-in function %s()", call)
+			e.hasError = true
+			return
+		case "uintptr":
+			e.tr.addError(typ.Fun.Pos(), "unsupported-"+call,
+				"conversion of type %s", call)
+			e.hasError = true
+			return
 
 		// Defined functions
 		default:
-			args := ""
-
-			for i, v := range typ.Args {
-				if i != 0 {
-					args += "," + SP
-				}
-				args += e.tr.getExpression(v).String()
-			}
-
-			e.WriteString(fmt.Sprintf("%s(%s)", call, args))
+			e.WriteString(fmt.Sprintf("%s(%s)", call, e.tr.joinArgs(typ)))
 		}
 
 	// godoc go/ast ChanType
@@ -409,7 +614,7 @@ func (e *expression) transform(expr ast.Expr) {
 	//  Dir   ChanDir   // channel direction
 	//  Value Expr      // value type
 	case *ast.ChanType:
-		e.tr.addError("%s: channel type", e.tr.fset.Position(typ.Pos()))
+		e.tr.addError(typ.Pos(), "unsupported-channel", "channel type")
 		e.tr.hasError = true
 		return
 
@@ -421,13 +626,33 @@ func (e *expression) transform(expr ast.Expr) {
 	case *ast.CompositeLit:
 		switch compoType := typ.Type.(type) {
 		case *ast.ArrayType:
+			// Slice
+			if compoType.Len == nil && e.tr.typeOf(typ) != nil && !e.tr.opts.Bootstrap {
+				e.transform(typ.Type) // type checking
+
+				e.WriteString("g.NewSlice([")
+				e.writeElts(typ)
+				e.WriteString("]," + SP + "0)")
+				break
+			}
+
+			// Array
+			if t := e.tr.typeOf(typ); t != nil && !e.tr.opts.Bootstrap {
+				if e.tr.getExpression(typ.Type).hasError {
+					e.hasError = true
+					return
+				}
+				e.writeArrayLit(typ, t.Underlying().(*types.Array))
+				break
+			}
+
 			if !e.arrayHasElts {
 				e.transform(typ.Type)
 			}
 
 			if e.isEllipsis {
 				e.WriteString("[")
-				e.writeElts(typ.Elts, typ.Lbrace, typ.Rbrace)
+				e.writeElts(typ)
 				e.WriteString("]")
 				break
 			}
@@ -443,13 +668,16 @@ func (e *expression) transform(expr ast.Expr) {
 					e.arrayHasElts = true
 				}
 				e.WriteString("[")
-				e.writeElts(typ.Elts, typ.Lbrace, typ.Rbrace)
+				e.writeElts(typ)
 				e.WriteString("]")
 
 				e.skipSemicolon = false
 			}
 
 		case *ast.Ident: // Custom types
+			if e.writeNamedLit(typ) {
+				break
+			}
 			useField := false
 			e.WriteString("new " + typ.Type.(*ast.Ident).Name)
 
@@ -458,13 +686,25 @@ func (e *expression) transform(expr ast.Expr) {
 				if _, ok := typ.Elts[0].(*ast.KeyValueExpr); ok {
 					useField = true
 
-					e.WriteString("();")
+					// The fields not specified have their zero value.
+					zero := ""
+					if t := e.tr.typeOf(typ); t != nil && isComposite(t) {
+						zero = e.tr.zeroOfType(typ.Type.(*ast.Ident))
+					}
+					e.WriteString("(" + zero + ");")
 					e.writeTypeElts(typ.Elts, typ.Lbrace)
 				}
 			}
 			if !useField {
 				e.WriteString("(")
-				e.writeElts(typ.Elts, typ.Lbrace, typ.Rbrace)
+				e.writeElts(typ)
+
+				// The fields have their zero value.
+				if t := e.tr.typeOf(typ); len(typ.Elts) == 0 && t != nil {
+					if _, ok := t.Underlying().(*types.Struct); ok {
+						e.WriteString(e.tr.zeroOfType(typ.Type.(*ast.Ident)))
+					}
+				}
 				e.WriteString(")")
 			}
 
@@ -474,18 +714,38 @@ func (e *expression) transform(expr ast.Expr) {
 				return
 			}
 			e.tr.maps[e.tr.funcId][e.tr.blockId][e.tr.lastVarName] = void
-
-			e.WriteString("new g.M({")
-			e.writeElts(typ.Elts, typ.Lbrace, typ.Rbrace)
-			e.WriteString("}," + SP + e.tr.zeroOfMap(compoType) + ")")
+			e.writeMap(typ)
 
 		case nil:
+			t := e.tr.typeOf(typ)
+
+			// The type of the elements of a map.
+			if t != nil && dataTypeOf(t) == mapType {
+				e.writeMap(typ)
+				break
+			}
+			// The type of the elements is a struct.
+			if named, ok := t.(*types.Named); ok {
+				if st, ok := named.Underlying().(*types.Struct); ok {
+					e.writeStructElts(named.Obj().Name(), st, typ.Elts)
+					break
+				}
+			}
+			// The type of the elements is an array.
+			if t != nil {
+				if arr, ok := t.Underlying().(*types.Array); ok {
+					e.writeArrayLit(typ, arr)
+					break
+				}
+			}
 			e.WriteString("[")
-			e.writeElts(typ.Elts, typ.Lbrace, typ.Rbrace)
+			e.writeElts(typ)
 			e.WriteString("]")
 
 		default:
-			panic(fmt.Sprintf("'CompositeLit' unimplemented: %T", compoType))
+			e.tr.addError(compoType.Pos(), "unsupported-composite",
+				"composite literal of kind %s", nodeKind(compoType))
+			e.hasError = true
 		}
 
 	// godoc go/ast Ellipsis
@@ -499,17 +759,30 @@ func (e *expression) transform(expr ast.Expr) {
 	//
 	//  Type *FuncType  // function type
 	//  Body *BlockStmt // function body
+	//
+	// The function is written in its own buffer, since its body is built by
+	// statements.
 	case *ast.FuncLit:
-		e.transform(typ.Type)
+		buf, resultTypes, results := e.tr.Buffer, e.tr.resultTypes, e.tr.results
+		funcType, funcBody := e.tr.funcType, e.tr.funcBody
+		e.tr.Buffer = new(bytes.Buffer)
+		e.tr.writeFunc(nil, nil, typ.Type, typ.Body)
+
+		if sig, ok := e.tr.typeOf(typ).(*types.Signature); ok {
+			e.tr.resultTypes = sig.Results()
+		}
 		e.tr.getStatement(typ.Body)
+		e.WriteString(e.tr.String())
+		e.tr.Buffer, e.tr.resultTypes, e.tr.results = buf, resultTypes, results
+		e.tr.funcType, e.tr.funcBody = funcType, funcBody
 
 	// godoc go/ast FuncType
 	//  Func    token.Pos  // position of "func" keyword
 	//  Params  *FieldList // (incoming) parameters; or nil
 	//  Results *FieldList // (outgoing) results; or nil
+	//
+	// It has not translation, since the functions are not typed in JavaScript.
 	case *ast.FuncType:
-		//e.isFunc = true
-		e.tr.writeFunc(nil, nil, typ)
 
 	// godoc go/ast Ident
 	//  Name    string    // identifier name
@@ -532,40 +805,45 @@ func (e *expression) transform(expr ast.Expr) {
 			e.isBasicLit = true
 			e.isNil = true
 
-		// Not supported
-		case "int64", "uint64", "complex64", "complex128":
-			e.tr.addError("%s: %s type", e.tr.fset.Position(typ.Pos()), name)
-			e.tr.hasError = true
 		// Not implemented
 		case "uintptr":
-			e.tr.addError("%s: unimplemented type %q", e.tr.fset.Position(typ.Pos()), name)
+			e.tr.addError(typ.Pos(), "unsupported-"+name, "unimplemented type %q", name)
 			e.tr.hasError = true
 
 		default:
-			if e.isPointer { // `*x` => `x.p`
-				name += ".p"
-			} else if e.isAddress { // `&x` => `x`
-				e.tr.addPointer(name)
-			} else {
-				if !e.tr.isVar {
-					isSlice := false
+			// The constants of some numbers are objects.
+			if isObject(numberOf(e.tr.typeOf(typ))) && e.writeConst(typ) {
+				break
+			}
+			if e.tr.info != nil && isHiddenConst(e.tr.info.Uses[typ]) && e.writeConst(typ) {
+				break
+			}
 
-					if e.tr.isType(sliceType, name) {
-						isSlice = true
-					}
-					/*if name == e.tr.recvVar {
-						name = "this"
-					}*/
-					if isSlice {
-						name += ".f" // slice field
-					}
+			// The variables addressed are boxes: `&x` => `x`, `x` => `x.p`
+			if e.isAddress {
+				e.WriteString(name)
+				break
+			}
 
-					if _, ok := e.tr.vars[e.tr.funcId][e.tr.blockId][name]; ok {
-						name += tagPointer(false, 'P', e.tr.funcId, e.tr.blockId, name)
-					}
-				} else {
-					e.isIdent = true
+			if !e.tr.isVar {
+				isSlice := false
+
+				// Without type information, the slices are used
+				// like arrays.
+				if e.tr.typeOf(typ) == nil && e.tr.isType(sliceType, nil, name) {
+					isSlice = true
 				}
+				if name == e.tr.recvVar {
+					name = "this"
+				}
+				if isSlice {
+					name += ".f" // slice field
+				}
+			} else {
+				e.isIdent = true
+			}
+			if e.tr.isAddressed(typ) {
+				name += ".p"
 			}
 
 			e.WriteString(name)
@@ -578,11 +856,23 @@ func (e *expression) transform(expr ast.Expr) {
 	//  Index  Expr      // index expression
 	//  Rbrack token.Pos // position of "]"
 	case *ast.IndexExpr:
+		// The byte of a string.
+		if isString(e.tr.typeOf(typ.X)) {
+			x := e.tr.getExpression(typ.X)
+			e.WriteString(fmt.Sprintf("%s.charCodeAt(%s)", paren(x.String(), x.prec, 11),
+				e.tr.getExpression(typ.Index)))
+			break
+		}
+		if e.tr.isType(mapType, typ.X, "") {
+			e.writeMapIndex(typ)
+			break
+		}
+
 		// == Store indexes
 		e.index = append(e.index, e.tr.getExpression(typ.Index).String())
 
 		// Could be multi-dimensional
-		if _, ok := typ.X.(*ast.IndexExpr); ok {
+		if x, ok := typ.X.(*ast.IndexExpr); ok && !e.tr.isType(mapType, x.X, "") {
 			e.transform(typ.X)
 			return
 		}
@@ -602,15 +892,15 @@ func (e *expression) transform(expr ast.Expr) {
 			indexArgs += idx
 		}
 
-		if e.tr.isType(mapType, x) {
+		if e.tr.isType(mapType, typ.X, x) {
 			e.mapName = x
 
-			if e.tr.isVar && !e.isValue {
-				e.WriteString(x + ".f" + index)
+			if e.isTarget {
+				e.WriteString(x + ".entry(" + indexArgs + ")[1]")
 			} else {
 				e.WriteString(x + ".get(" + indexArgs + ")[0]")
 			}
-		} else if e.tr.isType(sliceType, x) {
+		} else if e.tr.isType(sliceType, typ.X, x) {
 			e.WriteString(x + ".f" + index)
 		} else {
 			e.WriteString(x + index)
@@ -620,23 +910,25 @@ func (e *expression) transform(expr ast.Expr) {
 	//  Interface  token.Pos  // position of "interface" keyword
 	//  Methods    *FieldList // list of methods
 	//  Incomplete bool       // true if (source) methods are missing in the Methods list
-	case *ast.InterfaceType: // TODO: review
+	case *ast.InterfaceType:
 
 	// godoc go/ast KeyValueExpr
 	//  Key   Expr
 	//  Colon token.Pos // position of ":"
 	//  Value Expr
 	case *ast.KeyValueExpr:
-		key := e.tr.getExpression(typ.Key).String()
+		exprKey := e.tr.getExpression(typ.Key)
+		key := exprKey.String()
 		exprValue := e.tr.getExpression(typ.Value)
-		value := exprValue.String()
+		value := e.tr.copyValue(typ.Value, exprValue.String(), exprValue.prec)
 
-		if value[0] == '[' { // multi-dimensional index
-			value = "{" + value[1:len(value)-1] + "}"
+		if e.inMap { // the key is stored
+			key = e.tr.copyValue(typ.Key, key, exprKey.prec)
+			e.WriteString("[" + key + "," + SP + value + "]")
+		} else {
+			e.WriteString(key + ":" + SP + value)
 		}
 
-		e.WriteString(key + ":" + SP + value)
-
 	// godoc go/ast MapType
 	//  Map   token.Pos // position of "map" keyword
 	//  Key   Expr
@@ -651,51 +943,57 @@ func (e *expression) transform(expr ast.Expr) {
 	//  X      Expr      // parenthesized expression
 	//  Rparen token.Pos // position of ")"
 	case *ast.ParenExpr:
+		e.WriteString("(")
 		e.transform(typ.X)
+		e.WriteString(")")
+		e.prec = 0
 
 	// godoc go/ast SelectorExpr
 	//   X   Expr   // expression
 	//   Sel *Ident // field selector
 	case *ast.SelectorExpr:
+		isCallee := e.isCallee
+		e.isCallee = false
+
+		if e.writeMethodValue(typ, isCallee) {
+			break
+		}
+
 		isPkg := false
 		x := ""
+		sel := e.tr.embeddedPath(typ) + "." + typ.Sel.Name
 
 		switch t := typ.X.(type) {
 		case *ast.SelectorExpr:
 			e.transform(typ.X)
 		case *ast.Ident:
 			x = t.Name
-		case *ast.IndexExpr:
+		case *ast.IndexExpr, *ast.CallExpr, *ast.ParenExpr:
 			e.transform(t)
-			e.WriteString("." + typ.Sel.Name)
+			e.WriteString(sel)
 			return
 		default:
-			panic(fmt.Sprintf("'SelectorExpr': unimplemented: %T", t))
+			e.tr.addError(t.Pos(), "unsupported-selector",
+				"selector of an expression of kind %s", nodeKind(t))
+			e.hasError = true
+			return
 		}
 
-		if x == e.tr.recvVar {
+		if x != "" && x == e.tr.recvVar {
 			x = "this"
 		}
 		goName := x + "." + typ.Sel.Name
 
 		// Check is the selector is a package
-		for _, v := range validImport {
-			if v == x {
-				isPkg = true
-				break
-			}
-		}
+		isPkg = e.tr.isLibrary(x)
 
 		// Check if it can be transformed to its equivalent in JavaScript.
 		if isPkg {
-			jsName, ok := Function[goName]
-			if !ok {
-				jsName, ok = Constant[goName]
-			}
+			jsName, ok := e.tr.libraryName(goName)
 
 			if !ok {
-				e.tr.addError(fmt.Errorf("%s: %q not supported in JS",
-					e.tr.fset.Position(typ.Sel.Pos()), goName))
+				e.tr.addError(typ.Sel.Pos(), "unsupported-library",
+					"%q not supported in JS", goName)
 				e.tr.hasError = true
 				break
 			}
@@ -707,7 +1005,7 @@ func (e *expression) transform(expr ast.Expr) {
 				panic("selector: " + x)
 			}*/
 
-			e.WriteString(goName)
+			e.WriteString(x + sel)
 		}
 
 	// godoc go/ast SliceExpr
@@ -717,8 +1015,41 @@ func (e *expression) transform(expr ast.Expr) {
 	//  High   Expr      // end of slice range; or nil
 	//  Rbrack token.Pos // position of "]"
 	case *ast.SliceExpr:
+		// The bytes of a string, in a new string.
+		if isString(e.tr.typeOf(typ.X)) {
+			x := e.tr.getExpression(typ.X)
+			args := "0"
+
+			if typ.Low != nil {
+				args = e.tr.getExpression(typ.Low).String()
+			}
+			if typ.High != nil {
+				args += "," + SP + e.tr.getExpression(typ.High).String()
+			}
+
+			e.WriteString(fmt.Sprintf("%s.slice(%s)", paren(x.String(), x.prec, 11), args))
+			break
+		}
+
 		slice := "0"
-		x := typ.X.(*ast.Ident).Name
+
+		ident, ok := typ.X.(*ast.Ident)
+		if !ok {
+			e.tr.addError(typ.X.Pos(), "unsupported-slice",
+				"slice of an expression of kind %s", nodeKind(typ.X))
+			e.hasError = true
+			return
+		}
+		x := ident.Name
+
+		for _, v := range []ast.Expr{typ.Low, typ.High} {
+			if _, ok := v.(*ast.BasicLit); v != nil && !ok {
+				e.tr.addError(v.Pos(), "unsupported-slice",
+					"index of slice which is not a literal")
+				e.hasError = true
+				return
+			}
+		}
 
 		if typ.Low != nil {
 			slice = typ.Low.(*ast.BasicLit).Value // e.tr.getExpression(typ.Low).String()
@@ -745,10 +1076,18 @@ func (e *expression) transform(expr ast.Expr) {
 	// godoc go/ast StarExpr
 	//  Star token.Pos // position of "*"
 	//  X    Expr      // operand
+	//
+	// The pointers to structs and arrays are the values pointed.
 	case *ast.StarExpr:
-		e.isPointer = true
 		e.transform(typ.X)
 
+		// The value pointed is in the box, but the structs and arrays.
+		if t := e.tr.typeOf(typ); t != nil && (isComposite(t) || isCompositePointer(t) ||
+			e.tr.info.Types[typ].IsType()) {
+			break
+		}
+		e.WriteString(".p")
+
 	// godoc go/ast UnaryExpr
 	//  OpPos token.Pos   // position of Op
 	//  Op    token.Token // operator
@@ -763,30 +1102,110 @@ func (e *expression) transform(expr ast.Expr) {
 			op = "~"
 		// Address operator
 		case token.AND:
-			e.isAddress = true
+			// The structs and arrays are objects, which are their address;
+			// and the variables addressed are boxes.
+			if t := e.tr.typeOf(typ.X); t == nil || !isComposite(t) {
+				if _, ok := ast.Unparen(typ.X).(*ast.Ident); !ok {
+					e.tr.addError(typ.OpPos, "unsupported-address",
+						"address of a field or an element which is not a struct nor an array")
+					e.hasError = true
+					return
+				}
+				e.isAddress = true
+			}
 			writeOp = false
 		case token.ARROW:
-			e.tr.addError("%s: channel operator", e.tr.fset.Position(typ.OpPos))
+			e.tr.addError(typ.OpPos, "unsupported-channel", "channel operator")
 			e.tr.hasError = true
 			return
 		}
 
+		if e.writeConst(typ) {
+			break
+		}
+
+		if b := numberOf(e.tr.typeOf(typ)); isObject(b) {
+			x := e.tr.getExpression(typ.X)
+
+			switch typ.Op {
+			case token.SUB:
+				e.WriteString(paren(x.String(), x.prec, 11) + ".neg()")
+			case token.XOR:
+				e.WriteString(paren(x.String(), x.prec, 11) + ".not()")
+			default:
+				e.WriteString(x.String())
+				e.prec = x.prec
+			}
+			break
+		}
+
+		// The complement of 32 bits is not valid for int.
+		if b := numberOf(e.tr.typeOf(typ)); b != nil && isWide(b) &&
+			typ.Op == token.XOR && b.Info()&types.IsUnsigned == 0 {
+			e.WriteString("g.Xor(" + e.tr.getExpression(typ.X).String() + "," + SP + "-1)")
+			break
+		}
+
+		// The integers could overflow.
+		if b := numberOf(e.tr.typeOf(typ)); b != nil && isSized(b.Kind()) &&
+			(typ.Op == token.SUB || typ.Op == token.XOR && b.Info()&types.IsUnsigned != 0) {
+			x := e.tr.getExpression(typ.X)
+			js, prec := truncate(b, op+paren(x.String(), x.prec, 11), 0)
+
+			e.WriteString(js)
+			e.prec = prec
+			break
+		}
+
 		if writeOp {
 			e.WriteString(op)
 		}
 		e.transform(typ.X)
 
+	// godoc go/ast TypeAssertExpr
+	//  X      Expr // expression
+	//  Type   Expr // asserted type; nil means type switch X.(type)
+	case *ast.TypeAssertExpr:
+		e.writeTypeAssert(typ, false)
+
 	// The type has not been indicated
 	case nil:
 
 	default:
-		panic(fmt.Sprintf("unimplemented: %T", expr))
+		e.tr.addError(expr.Pos(), "unsupported-expression",
+			"expression of kind %s", nodeKind(expr))
+		e.hasError = true
 	}
 }
 
 //
 // === Utility
 
+// Writes the value of "expr" if it is a constant, calculated by the type
+// checker. Returns false if it is not.
+func (e *expression) writeConst(expr ast.Expr) bool {
+	if e.tr.info == nil {
+		return false
+	}
+	tv, ok := e.tr.info.Types[expr]
+	if !ok || tv.Value == nil {
+		return false
+	}
+
+	lit, _ := e.tr.constLiteral(expr.Pos(), tv.Type, tv.Value)
+	e.WriteString(lit)
+	e.isBasicLit = true
+	return true
+}
+
+// Returns the expression indexed in "expr", if it is an index expression.
+func indexedExpr(expr ast.Expr) ast.Expr {
+	if index, ok := expr.(*ast.IndexExpr); ok {
+		return index.X
+	}
+	return nil
+}
+
 // Appends a new length of array.
 func (e *expression) addLenArray(expr ast.Expr) {
 	e.lenArray = append(e.lenArray, e.tr.getExpression(expr).String())
@@ -797,7 +1216,7 @@ func (e *expression) printArray() string {
 	a := ""
 
 	for i := 0; i < len(e.lenArray); i++ {
-		vArray := string('i' + i)
+		vArray := string(rune('i' + i))
 		a += fmt.Sprintf("[%s]", vArray)
 	}
 	return a
@@ -806,43 +1225,106 @@ func (e *expression) printArray() string {
 // Writes the loop for the last length of the array.
 func (e *expression) writeLoop() {
 	iArray := len(e.lenArray) - 1  // index of array
-	vArray := string('i' + iArray) // variable's name for the loop
+	vArray := string(rune('i' + iArray)) // variable's name for the loop
 
 	e.WriteString(fmt.Sprintf(";%sfor%s(var %s=0;%s<%s;%s++)",
 		SP, SP, vArray, SP+vArray, e.lenArray[iArray], SP+vArray))
 }
 
 // Writes the list of composite elements.
-func (e *expression) writeElts(elts []ast.Expr, Lbrace, Rbrace token.Pos) {
-	firstPos := e.tr.getLine(Lbrace)
-	posOldElt := firstPos
+func (e *expression) writeElts(lit *ast.CompositeLit) {
+	elts := lit.Elts
+	typ := e.tr.typeOf(lit)
+	posOldElt := e.tr.getLine(lit.Lbrace)
 	posNewElt := 0
 
+	// The global position is updated at each element, since the statements of
+	// the function literals add lines too.
 	for i, el := range elts {
 		posNewElt = e.tr.getLine(el.Pos())
 
 		if i != 0 {
 			e.WriteString(",")
 		}
-		if posNewElt != posOldElt {
+		if posNewElt > posOldElt {
 			e.WriteString(strings.Repeat(NL, posNewElt - posOldElt))
 			e.WriteString(strings.Repeat(TAB, e.tr.tabLevel + 1))
+			e.tr.line += posNewElt - posOldElt
+			posOldElt = posNewElt
 		} else if i != 0 { // in the same line
 			e.WriteString(SP)
 		}
 
-		e.transform(el)
-		posOldElt = posNewElt
+		to := eltType(typ, i)
+
+		if kv, ok := el.(*ast.KeyValueExpr); ok && typ != nil && dataTypeOf(typ) == mapType {
+			e.WriteString(e.tr.mapEntry(typ.Underlying().(*types.Map), kv))
+		} else if e.tr.isShared(el) || e.tr.needsBox(to, el) {
+			x := e.tr.getExpression(el)
+			e.WriteString(e.tr.valueTo(to, el, x.String(), x.prec))
+		} else {
+			e.transform(el)
+		}
+		if e.tr.line > posOldElt { // lines added by a function literal
+			posOldElt = e.tr.line
+		}
 	}
 
 	// The right brace
-	posNewElt = e.tr.getLine(Rbrace)
-	if posNewElt != posOldElt {
+	posNewElt = e.tr.getLine(lit.Rbrace)
+	if posNewElt > posOldElt {
 		e.WriteString(strings.Repeat(NL, posNewElt - posOldElt))
 		e.WriteString(strings.Repeat(TAB, e.tr.tabLevel))
+		e.tr.line += posNewElt - posOldElt
+	}
+}
+
+// Writes the composite literal of a named type which is not a struct, like the
+// one of its underlying type; and reports whether it was written.
+func (e *expression) writeNamedLit(lit *ast.CompositeLit) bool {
+	typ := e.tr.typeOf(lit)
+	if typ == nil {
+		return false
 	}
 
-	e.tr.line += posNewElt - firstPos // update the global position
+	switch t := typ.Underlying().(type) {
+	case *types.Map:
+		e.tr.maps[e.tr.funcId][e.tr.blockId][e.tr.lastVarName] = void
+		e.writeMap(lit)
+	case *types.Slice:
+		e.WriteString("g.NewSlice([")
+		e.writeElts(lit)
+		e.WriteString("]," + SP + "0)")
+	case *types.Array:
+		e.writeArrayLit(lit, t)
+	default:
+		return false
+	}
+	return true
+}
+
+// Writes the composite literal of an array like an expression, whose elements
+// not specified have their zero value.
+func (e *expression) writeArrayLit(lit *ast.CompositeLit, t *types.Array) {
+	if len(lit.Elts) == 0 {
+		zero, _ := e.tr.zeroOf(true, t)
+		e.WriteString(zero)
+		return
+	}
+	if _, ok := lit.Elts[0].(*ast.KeyValueExpr); ok {
+		e.tr.addError(lit.Pos(), "unsupported-composite",
+			"composite literal of array with keys")
+		e.hasError = true
+		return
+	}
+	e.WriteString("[")
+	e.writeElts(lit)
+
+	zero, _ := e.tr.zeroOf(true, t.Elem())
+	for i := int64(len(lit.Elts)); i < t.Len(); i++ {
+		e.WriteString("," + SP + zero)
+	}
+	e.WriteString("]")
 }
 
 // Writes the list of elements for a custom type.
@@ -880,10 +1362,11 @@ func (e *expression) writeTypeElts(elts []ast.Expr, Lbrace token.Pos) {
 			e.WriteString(SP)
 		}
 
+		value := e.tr.getExpression(kv.Value)
 		e.WriteString(fmt.Sprintf("%s%s=%s",
 			e.tr.lastVarName,
 			key + SP,
-			SP + e.tr.getExpression(kv.Value).String(),
+			SP + e.tr.valueTo(e.tr.typeOf(kv.Key), kv.Value, value.String(), value.prec),
 		))
 
 		posOldElt = posNewElt
@@ -891,6 +1374,36 @@ func (e *expression) writeTypeElts(elts []ast.Expr, Lbrace token.Pos) {
 	e.tr.line += posNewElt - firstPos // update the global position
 }
 
+// Writes the construction of the struct "name" whose type is elided in a
+// composite literal; the fields without element have their zero value.
+func (e *expression) writeStructElts(name string, st *types.Struct, elts []ast.Expr) {
+	values := make([]string, st.NumFields())
+
+	for i, el := range elts {
+		field := i
+		if kv, ok := el.(*ast.KeyValueExpr); ok {
+			for j := 0; j < st.NumFields(); j++ {
+				if st.Field(j).Name() == kv.Key.(*ast.Ident).Name {
+					field = j
+				}
+			}
+			el = kv.Value
+		}
+		x := e.tr.getExpression(el)
+		values[field] = e.tr.valueTo(st.Field(field).Type(), el, x.String(), x.prec)
+	}
+
+	for i, v := range values {
+		if v == "" {
+			if v, _ = e.tr.zeroOf(true, st.Field(i).Type()); v == "" { // map
+				v = "undefined"
+			}
+		}
+		values[i] = v
+	}
+	e.WriteString(fmt.Sprintf("new %s(%s)", name, strings.Join(values, ","+SP)))
+}
+
 // * * *
 
 // Strips the field name ".f".
@@ -17,6 +17,8 @@ package gojs
 import (
 	"fmt"
 	"go/ast"
+	"go/token"
+	"go/types"
 )
 
 // Functions
@@ -35,6 +37,15 @@ func (tr *transform) getFunc(decl *ast.FuncDecl) {
 	if len(decl.Body.List) == 0 {
 		return
 	}
+	if decl.Recv != nil && embeddedName(decl.Recv.List[0].Type) == nil {
+		recvType := decl.Recv.List[0].Type
+		if star, ok := recvType.(*ast.StarExpr); ok {
+			recvType = star.X
+		}
+		tr.addError(recvType.Pos(), "unsupported-method",
+			"receiver of kind %s", nodeKind(recvType))
+		return
+	}
 
 	isFuncInit := false // function init()
 
@@ -44,8 +55,7 @@ func (tr *transform) getFunc(decl *ast.FuncDecl) {
 		tr.funcId = tr.funcTotal
 		tr.blockId = 0
 
-		tr.vars[tr.funcId] = make(map[int]map[string]bool)
-		tr.addr[tr.funcId] = make(map[int]map[string]bool)
+		tr.vars[tr.funcId] = make(map[int]map[string]struct{})
 		tr.maps[tr.funcId] = make(map[int]map[string]struct{})
 		tr.slices[tr.funcId] = make(map[int]map[string]struct{})
 		tr.zeroType[tr.funcId] = make(map[int]map[string]string)
@@ -53,10 +63,14 @@ func (tr *transform) getFunc(decl *ast.FuncDecl) {
 	// ==
 
 	tr.addLine(decl.Pos())
-	tr.addIfExported(decl.Name)
+	tr.addMark(decl.Pos(), "")
+	if decl.Recv == nil { // the methods are exported through their type
+		tr.addIfExported(decl.Name)
+		tr.WriteString(tr.exportAt(decl.Name))
+	}
 
 	if decl.Name.Name != "init" {
-		tr.writeFunc(decl.Recv, decl.Name, decl.Type)
+		tr.writeFunc(decl.Recv, decl.Name, decl.Type, decl.Body)
 	} else {
 		isFuncInit = true
 		tr.WriteString("(function()" + SP)
@@ -96,21 +110,87 @@ func (tr *transform) getFunc(decl *ast.FuncDecl) {
 //  Comment *CommentGroup // line comments; or nil
 
 // Writes the function declaration.
-func (tr *transform) writeFunc(recv *ast.FieldList, name *ast.Ident, typ *ast.FuncType) {
+func (tr *transform) writeFunc(recv *ast.FieldList, name *ast.Ident, typ *ast.FuncType, body *ast.BlockStmt) {
+	copies := ""
+	tr.funcType, tr.funcBody = typ, body
+
 	if recv != nil { // method
 		field := recv.List[0]
-		tr.recvVar = field.Names[0].Name
+		tr.recvVar = ""
+		if len(field.Names) != 0 {
+			tr.recvVar = field.Names[0].Name
+		}
 
-		tr.WriteString(fmt.Sprintf("%s.prototype.%s%s=%sfunction(%s)%s",
-			field.Type, name,SP, SP, joinParams(typ), SP))
+		// The methods of the named types which are not structs are functions,
+		// which get the receiver like the first argument.
+		if fun := tr.staticMethodOf(name); fun != "" {
+			recvName := tr.recvVar
+			if recvName == "" {
+				recvName = "_"
+			}
+			params := recvName
+			if p := joinParams(typ); p != "" {
+				params += "," + SP + p
+			}
+			tr.recvVar = ""
+
+			// The function is exported if both the type and the method are.
+			if ast.IsExported(name.Name) {
+				tr.addIfExported(fun)
+				tr.WriteString(tr.exportAt(&ast.Ident{NamePos: name.Pos(), Name: fun}))
+			}
+			tr.WriteString(fmt.Sprintf("function %s(%s)%s",
+				tr.mark(name.Pos(), fun)+fun, params, SP))
+
+			// The receiver is a value, so a named array is copied if it could
+			// be modified.
+			if t := tr.typeOf(field.Type); t != nil && recvName != "_" &&
+				isComposite(t) && tr.isModified(body, recvName) {
+				copies = fmt.Sprintf("%s=%s;", recvName+SP, SP+tr.cloneOf(t, recvName, 0))
+			}
+			copies += tr.boxParams(recv)
+		} else {
+			// The methods of "T" and "*T" are in the prototype of "T".
+			tr.WriteString(fmt.Sprintf("%s.prototype.%s%s=%sfunction(%s)%s",
+				embeddedName(field.Type).Name, tr.mark(name.Pos(), name.Name)+name.Name, SP, SP,
+				joinParams(typ), SP))
+		}
+
+		// The receiver is a value, so the method works on a copy; and "this"
+		// can not be assigned, nor used by a function literal.
+		if t := tr.typeOf(field.Type); t != nil && tr.recvVar != "" {
+			if box := tr.defValue(field.Names[0], "this"); box != "this" {
+				copies = fmt.Sprintf("var %s=%s;", tr.recvVar+SP, SP+box)
+				tr.recvVar = ""
+			} else if isComposite(t) && tr.isModified(body, tr.recvVar) {
+				copies = fmt.Sprintf("var %s=%s;", tr.recvVar+SP, SP+tr.cloneOf(t, "this", 0))
+				tr.recvVar = ""
+			} else if needsRecvVar(body, tr.recvVar) {
+				copies = fmt.Sprintf("var %s=%sthis;", tr.recvVar+SP, SP)
+				tr.recvVar = ""
+			}
+		}
 	} else if name != nil {
-		tr.WriteString(fmt.Sprintf("function %s(%s)%s", name, joinParams(typ), SP))
+		tr.WriteString(fmt.Sprintf("function %s(%s)%s",
+			tr.mark(name.Pos(), name.Name)+name.Name, joinParams(typ), SP))
 	} else { // Literal function
-		tr.WriteString(fmt.Sprintf("%s=%sfunction(%s)%s", SP, SP, joinParams(typ), SP))
+		tr.WriteString(fmt.Sprintf("function(%s)%s", joinParams(typ), SP))
+	}
+
+	if body != nil {
+		copies += tr.copyParams(typ, body) + tr.boxParams(typ.Params)
+	}
+
+	tr.resultTypes = nil
+	if name != nil && tr.info != nil {
+		if obj := tr.info.Defs[name]; obj != nil {
+			tr.resultTypes = obj.Type().(*types.Signature).Results()
+		}
 	}
 
 	// Return multiple values
 	declResults, declReturn := tr.joinResults(typ)
+	declResults = copies + declResults
 
 	if declResults != "" {
 		tr.WriteString("{" + SP + declResults)
@@ -121,6 +201,125 @@ func (tr *transform) writeFunc(recv *ast.FieldList, name *ast.Ident, typ *ast.Fu
 	}
 }
 
+// Reports whether the receiver "name" of the method with the body has to be
+// declared in a variable: if it is assigned, or used by a function literal.
+func needsRecvVar(body *ast.BlockStmt, name string) bool {
+	found := false
+
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch t := node.(type) {
+		case *ast.FuncLit:
+			ast.Inspect(t.Body, func(node ast.Node) bool {
+				if ident, ok := node.(*ast.Ident); ok && ident.Name == name {
+					found = true
+				}
+				return !found
+			})
+		case *ast.AssignStmt:
+			for _, v := range t.Lhs {
+				if ident, ok := v.(*ast.Ident); ok && ident.Name == name {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// Returns the type of the result "i" of the actual function, which returns "n"
+// values; or nil if it is unknown.
+func (tr *transform) resultType(i, n int) types.Type {
+	if tr.resultTypes == nil || tr.resultTypes.Len() != n {
+		return nil
+	}
+	return tr.resultTypes.At(i).Type()
+}
+
+/*
+## Function values
+
+The values of function types are functions of JavaScript, and their nil value
+is "undefined". A value which could be nil is called through "g.Func", which
+panics if it is nil:
+
+	h(2)             =>  g.Func(h)(2)
+	s.ops[0].fn(1)   =>  g.Func(s.ops.f[0].fn)(1)
+*/
+
+// Reports whether the function called by "fun" is a value of a function type,
+// which could be nil: a variable, a field, or the result of an expression.
+func (tr *transform) isFuncValue(fun ast.Expr) bool {
+	if tr.info == nil {
+		return false
+	}
+
+	switch t := fun.(type) {
+	case *ast.Ident:
+		obj, ok := tr.info.Uses[t].(*types.Var)
+		return ok && !tr.funcLits[obj]
+	case *ast.SelectorExpr:
+		if sel, ok := tr.info.Selections[t]; ok {
+			return sel.Kind() == types.FieldVal
+		}
+		_, ok := tr.info.Uses[t.Sel].(*types.Var) // variable of a package
+		return ok
+	case *ast.ParenExpr:
+		return tr.isFuncValue(t.X)
+	case *ast.FuncLit:
+		return false
+	}
+	return tr.info.Types[fun].IsValue()
+}
+
+// Returns the variables declared with a function literal which are not
+// assigned later, so they are never nil.
+func funcLitVars(files []*ast.File, info *types.Info) map[types.Object]bool {
+	lits := make(map[types.Object]bool)
+	assigned := make(map[types.Object]bool)
+
+	declare := func(names []*ast.Ident, values []ast.Expr) {
+		for i, name := range names {
+			if obj := info.Defs[name]; obj != nil && len(values) == len(names) {
+				if _, ok := values[i].(*ast.FuncLit); ok {
+					lits[obj] = true
+				}
+			}
+		}
+	}
+	assign := func(expr ast.Expr) {
+		if ident, ok := expr.(*ast.Ident); ok && info.Uses[ident] != nil {
+			assigned[info.Uses[ident]] = true
+		}
+	}
+
+	for _, file := range files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch t := node.(type) {
+			case *ast.ValueSpec:
+				declare(t.Names, t.Values)
+			case *ast.AssignStmt:
+				names := make([]*ast.Ident, len(t.Lhs))
+				for i, v := range t.Lhs {
+					names[i], _ = v.(*ast.Ident)
+					assign(v)
+				}
+				declare(names, t.Rhs)
+			case *ast.UnaryExpr:
+				if t.Op == token.AND {
+					assign(t.X)
+				}
+			}
+			return true
+		})
+	}
+
+	for obj := range assigned {
+		delete(lits, obj)
+	}
+	return lits
+}
+
 // Gets the parameters.
 func joinParams(f *ast.FuncType) string {
 	isFirst := true
@@ -171,8 +370,11 @@ func (tr *transform) joinResults(f *ast.FuncType) (decl, ret string) {
 				isFirst = false
 			}
 
-			decl += fmt.Sprintf("%s=%s", v.Name+SP, SP+value)
+			decl += fmt.Sprintf("%s=%s", v.Name+SP, SP+tr.defValue(v, value))
 			ret += v.Name
+			if tr.defValue(v, v.Name) != v.Name {
+				ret += ".p"
+			}
 		}
 	}
 
@@ -16,14 +16,15 @@ package gojs
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
+	"io"
 	"io/ioutil"
-	"os"
 	"path"
+	"path/filepath"
 	"strings"
 )
 
@@ -39,17 +40,14 @@ const (
 	SP  = "<<SP>>" // space
 	TAB = "<<TAB>>"
 
-	ADDR = "<<&>>" // to mark assignments to addresses
 	IOTA = "<<iota>>"
-	NIL  = "<<nil>>"
 )
 
 var void struct{} // A struct without any elements occupies no space at all.
 
-var (
-	Bootstrap  bool // is transforming the gojs's package?
-	MaxMessage = 10 // maximum number of errors and warnings to show.
-)
+// MaxMessage is the maximum number of errors and warnings to show, by
+// default.
+const MaxMessage = 10
 
 // Represents information about code being transformed to JavaScript.
 type transform struct {
@@ -60,17 +58,26 @@ type transform struct {
 	*bytes.Buffer // sintaxis translated to JS
 	*dataStmt     // extra data for a statement
 
-	err      []error  // errors
-	warn     []string // warnings
-	exported []string // declarations to be exported
+	err      []Diagnostic // errors
+	warn     []Diagnostic // warnings
+	exported []string     // declarations to be exported
+	marks    []srcMark    // points mapped to the Go source
+
+	opts       Options
+	pkgName    string
+	exportedAt map[string]bool // names exported at their declaration
+
+	info      *types.Info           // types of the package; or nil
+	funcLits  map[types.Object]bool // variables always set to a function literal
+	addressed map[types.Object]bool // variables whose address is taken
+	pkgNames  map[string]string     // local names of the imported packages, by path
 
 	//slice map[string]string // for range; key: function name, value: slice name
 	//function string // actual function
 
 	// == Variables defined in each block, for each function.
 	// {Function Id: {Block id: {Name:
-	vars   map[int]map[int]map[string]bool // is pointer?
-	addr   map[int]map[int]map[string]bool // variable was assigned to an address?
+	vars   map[int]map[int]map[string]struct{}
 	maps   map[int]map[int]map[string]struct{}
 	slices map[int]map[int]map[string]struct{}
 
@@ -78,24 +85,37 @@ type transform struct {
 	zeroType map[int]map[int]map[string]string
 }
 
-func newTransform() *transform {
+func newTransform(fset *token.FileSet, opts Options) *transform {
+	if opts.MaxMessage <= 0 {
+		opts.MaxMessage = MaxMessage
+	}
+
 	tr := &transform{
 		0,
 		false,
 
-		token.NewFileSet(),
+		fset,
 		new(bytes.Buffer),
 		&dataStmt{},
 
-		make([]error, 0, MaxMessage),
-		make([]string, 0, MaxMessage),
+		make([]Diagnostic, 0, opts.MaxMessage),
+		make([]Diagnostic, 0, opts.MaxMessage),
 		make([]string, 0),
+		make([]srcMark, 0),
+
+		opts,
+		"",
+		make(map[string]bool),
+
+		nil,
+		nil,
+		nil,
+		nil,
 
 		//make(map[string]string),
 		//"",
 
-		make(map[int]map[int]map[string]bool),
-		make(map[int]map[int]map[string]bool),
+		make(map[int]map[int]map[string]struct{}),
 		make(map[int]map[int]map[string]struct{}),
 		make(map[int]map[int]map[string]struct{}),
 		make(map[int]map[int]map[string]string),
@@ -107,15 +127,13 @@ func newTransform() *transform {
 	// file stmt: *transform.getStatement() (case: *ast.BlockStmt)
 
 	// funcId = 0
-	tr.vars[0] = make(map[int]map[string]bool)
-	tr.addr[0] = make(map[int]map[string]bool)
+	tr.vars[0] = make(map[int]map[string]struct{})
 	tr.maps[0] = make(map[int]map[string]struct{})
 	tr.slices[0] = make(map[int]map[string]struct{})
 	tr.zeroType[0] = make(map[int]map[string]string)
 
 	// blockId = 0
-	tr.vars[0][0] = make(map[string]bool)
-	tr.addr[0][0] = make(map[string]bool)
+	tr.vars[0][0] = make(map[string]struct{})
 	tr.maps[0][0] = make(map[string]struct{})
 	tr.slices[0][0] = make(map[string]struct{})
 	tr.zeroType[0][0] = make(map[string]string)
@@ -150,34 +168,14 @@ func (tr *transform) addLine(pos token.Pos) bool {
 	return true
 }
 
-// Appends an error.
-func (tr *transform) addError(value interface{}, a ...interface{}) {
-	if len(tr.err) == MaxMessage {
-		return
-	}
-
-	switch typ := value.(type) {
-	case string:
-		tr.err = append(tr.err, fmt.Errorf(typ, a...))
-	case error:
-		tr.err = append(tr.err, typ)
-	default:
-		panic("wrong type")
-	}
-
-	if !tr.hasError {
-		tr.hasError = true
+// Appends new lines according to the position of the package clause, unless
+// it is in the line of the header.
+func (tr *transform) addPkgLine(pos token.Pos) {
+	if tr.getLine(pos) > tr.line {
+		tr.addLine(pos)
 	}
 }
 
-// Appends a warning message.
-func (tr *transform) addWarning(format string, a ...interface{}) {
-	if len(tr.warn) == MaxMessage {
-		return
-	}
-	tr.warn = append(tr.warn, fmt.Sprintf(format, a...))
-}
-
 // Appends the declaration name if it is exported.
 func (tr *transform) addIfExported(iName interface{}) {
 	var name = ""
@@ -194,18 +192,98 @@ func (tr *transform) addIfExported(iName interface{}) {
 	}
 }
 
+// Returns the keyword to export a global declaration of "names" in an
+// ECMAScript module, if all of them are exported; else, they are exported
+// at the end of the module.
+func (tr *transform) exportAt(names ...*ast.Ident) string {
+	if tr.opts.Format != ESModule || tr.pkgName == "main" {
+		return ""
+	}
+	isExported := false
+
+	for _, v := range names {
+		if v.Name == BLANK {
+			continue
+		}
+		if !ast.IsExported(v.Name) {
+			return ""
+		}
+		isExported = true
+	}
+	if !isExported {
+		return ""
+	}
+
+	for _, v := range names {
+		tr.exportedAt[v.Name] = true
+	}
+	return "export "
+}
+
 // * * *
 
-// Compiles a Go source file into JavaScript.
-// Writes the output in "filename" but with extension ".js".
-func Compile(filename string) error {
-	trans := newTransform()
-	pkgName := ""
+// Format represents the kind of module written for a package.
+type Format uint8
+
+const (
+	// The package is wrapped in a function whose exported names are added
+	// to a global variable named like the package; to be loaded by a page.
+	IIFE Format = iota
+
+	// ECMAScript module, which uses "import" and "export"; to be loaded by
+	// bundlers and by modern browsers.
+	ESModule
+
+	// CommonJS module, which uses "require" and "module.exports"; to be
+	// loaded by Node.js.
+	CommonJS
+)
 
-	/* Parse several files
-	parse.ParseFile(fset, "a.go", nil, 0)
-	parse.ParseFile(fset, "b.go", nil, 0)
-	*/
+// Target represents the environment where the code is run.
+type Target uint8
+
+const (
+	// The output is written through the page or the console.
+	Browser Target = iota
+
+	// The output is written to the standard output of the process, and the
+	// package "os" is available. The function "main" is called at loading.
+	Node
+)
+
+// RUNTIME is the path of the module with the gojs's package, imported by
+// the ECMAScript and CommonJS modules.
+const RUNTIME = "./pkg.js"
+
+// Options represents the settings of a translation.
+type Options struct {
+	Minify bool   // write the minimized version instead of the one to debug
+	Format Format // kind of module to write
+	Target Target // environment where the code is run
+
+	Bootstrap  bool // is transforming the gojs's package?
+	MaxMessage int  // maximum number of errors and warnings to show; or MaxMessage if 0
+
+	// Constants and functions of the core library, with their equivalent in
+	// JavaScript, which replace the default ones. They are not modified.
+	Constant map[string]string
+	Function map[string]string
+
+	// Importer imports the packages which are not in the core library, to
+	// check the types. If nil, they are imported from their source code,
+	// found like in "go build".
+	Importer types.Importer
+}
+
+// Compiles a Go source file into JavaScript.
+// Writes the output in "filename" but with extension ".js", and the
+// minimized version with extension ".min.js"; besides their source maps.
+//
+// Returns the errors and warnings found in the source code; then, the error
+// is not nil if there is some error in the list. Any other error, like at
+// writing the output, is returned without diagnostics.
+func Compile(filename string, opts Options) (Diagnostics, error) {
+	fset := token.NewFileSet()
 
 	// godoc go/ast File
 	//  Doc        *CommentGroup   // associated documentation; or nil
@@ -217,72 +295,226 @@ func Compile(filename string) error {
 	//  Unresolved []*Ident        // unresolved identifiers in this file
 	//  Comments   []*CommentGroup // list of all comments in the source file
 
-	node, err := parser.ParseFile(trans.fset, filename, nil, 0) //parser.ParseComments)
+	node, err := parser.ParseFile(fset, filename, nil, 0) //parser.ParseComments)
 	if err != nil {
-		return err
+		if diag, ok := syntaxDiagnostics(err); ok {
+			return diag, diag
+		}
+		return nil, err
 	}
 
-	trans.WriteString(HEADER)
+	baseFilename := strings.Replace(filename, path.Ext(filename), "", 1)
+	return compileFiles(fset, []*ast.File{node}, baseFilename, opts)
+}
 
-	// Package name
-	pkgName = trans.getExpression(node.Name).String()
+// Compiles all Go source files of the package in directory "dir" into
+// JavaScript. The test files are skipped.
+// Writes the output in "dir", in files named like the package but with
+// extensions ".js" and ".min.js"; besides their source maps.
+//
+// The values returned are like in Compile.
+func CompilePackage(dir string, opts Options) (Diagnostics, error) {
+	fset := token.NewFileSet()
 
-	if pkgName != "main" {
-		trans.addLine(node.Package)
-		trans.WriteString(fmt.Sprintf("var %s=%s{};%s(function()%s{",
-			pkgName+SP, SP, SP, SP))
+	info, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, decl := range node.Decls {
-		switch decl.(type) {
-		case *ast.FuncDecl:
-			trans.getFunc(decl.(*ast.FuncDecl))
-
-		// godoc go/ast GenDecl
-		//  Tok    token.Token   // IMPORT, CONST, TYPE, VAR
-		//  Specs  []Spec
-		case *ast.GenDecl:
-			genDecl := decl.(*ast.GenDecl)
-
-			switch genDecl.Tok {
-			case token.IMPORT:
-				trans.getImport(genDecl.Specs)
-			case token.CONST:
-				trans.getConst(genDecl.TokPos, genDecl.Specs, true)
-			case token.TYPE:
-				trans.getType(genDecl.Specs, true)
-			case token.VAR:
-				trans.getVar(genDecl.Specs, true)
+	files := make([]*ast.File, 0)
+
+	for _, fi := range info { // sorted by filename
+		name := fi.Name()
+
+		if fi.IsDir() || !strings.HasSuffix(name, ".go") ||
+			strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		node, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			if diag, ok := syntaxDiagnostics(err); ok {
+				return diag, diag
 			}
+			return nil, err
+		}
 
-		default:
-			panic(fmt.Sprintf("unimplemented: %T", decl))
+		if len(files) != 0 && node.Name.Name != files[0].Name.Name {
+			return nil, fmt.Errorf("%s: found packages %s and %s", dir,
+				files[0].Name.Name, node.Name.Name)
 		}
+		files = append(files, node)
 	}
 
-	// Any error?
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%s: no Go source files", dir)
+	}
+
+	return compileFiles(fset, files, filepath.Join(dir, files[0].Name.Name), opts)
+}
+
+// Translates the files of a package and writes the output in "baseFilename"
+// with extension ".js", and the minimized version with extension ".min.js".
+// Every one has its source map, with extension ".map" added.
+func compileFiles(fset *token.FileSet, files []*ast.File, baseFilename string, opts Options) (Diagnostics, error) {
+	trans := newTransform(fset, opts)
+	trans.translate(files)
+
 	if trans.hasError {
-		fmt.Fprintln(os.Stderr, " == Errors\n")
+		diag := trans.diagnostics()
+		return diag, diag
+	}
 
-		for _, err := range trans.err {
-			fmt.Fprintf(os.Stderr, "%s\n", err)
+	for _, minimize := range []bool{false, true} {
+		jsFile := baseFilename + ".js"
+		if minimize {
+			jsFile = baseFilename + ".min.js"
 		}
-		if len(trans.err) == MaxMessage {
-			fmt.Fprintln(os.Stderr, "\n Too many errors")
+
+		js, maps := trans.output(minimize)
+		js += sourceMapURL(jsFile)
+
+		if err := ioutil.WriteFile(jsFile, []byte(js), 0664); err != nil {
+			return nil, err
 		}
+		err := ioutil.WriteFile(jsFile+".map", trans.sourceMap(jsFile, maps), 0664)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return trans.diagnostics(), nil
+}
+
+// Translate transforms the files of a package, parsed with "fset", into
+// one JavaScript module. The output is nil if there is some error in the
+// diagnostics.
+func Translate(fset *token.FileSet, files []*ast.File, opts Options) (js []byte, diag Diagnostics) {
+	var buf bytes.Buffer
+
+	// Writing to a buffer can not fail.
+	if diag, _ = TranslateTo(&buf, fset, files, opts); diag.HasErrors() {
+		return nil, diag
+	}
+	return buf.Bytes(), diag
+}
+
+// TranslateTo is like Translate but it writes the output to "w".
+// The error is either the diagnostics, if there is some error, or the one
+// got at writing.
+func TranslateTo(w io.Writer, fset *token.FileSet, files []*ast.File, opts Options) (Diagnostics, error) {
+	trans := newTransform(fset, opts)
+	trans.translate(files)
+
+	if trans.hasError {
+		diag := trans.diagnostics()
+		return diag, diag
+	}
+
+	js, _ := trans.output(opts.Minify)
+
+	_, err := io.WriteString(w, js)
+	return trans.diagnostics(), err
+}
 
-		return errors.New("") // to indicate that there was any error
+// TranslateMap is like Translate but it also returns the source map of the
+// output, named like "jsFile" plus extension ".map". The paths of the Go
+// files in the map are relative to the directory of "jsFile".
+func TranslateMap(fset *token.FileSet, files []*ast.File, opts Options, jsFile string) (js, smap []byte, diag Diagnostics) {
+	trans := newTransform(fset, opts)
+	trans.translate(files)
+
+	if trans.hasError {
+		return nil, nil, trans.diagnostics()
+	}
+
+	str, maps := trans.output(opts.Minify)
+	return []byte(str + sourceMapURL(jsFile)), trans.sourceMap(jsFile, maps),
+		trans.diagnostics()
+}
+
+// Transforms the files of a package into one JavaScript module.
+func (trans *transform) translate(files []*ast.File) {
+	if len(files) == 0 {
+		trans.addError(token.NoPos, "no-files", "no Go source files")
+		return
+	}
+	if trans.checkTypes(files); trans.hasError {
+		return
+	}
+	// The header is in its own line, which is the first one of the source.
+	trans.WriteString(HEADER + NL)
+	trans.line = 1
+
+	// Package name
+	pkgName := trans.getExpression(files[0].Name).String()
+	trans.pkgName = pkgName
+
+	isModule := trans.opts.Format != IIFE
+
+	if isModule {
+		if !trans.opts.Bootstrap {
+			trans.addPkgLine(files[0].Package)
+			trans.WriteString(trans.importModule("g", RUNTIME))
+		}
+	} else if pkgName != "main" {
+		trans.addPkgLine(files[0].Package)
+		trans.WriteString(fmt.Sprintf("var %s=%s{};%s(function()%s{",
+			pkgName+SP, SP, SP, SP))
+	}
+
+	for i, node := range files {
+		// The lines of every file are placed after the previous one.
+		if i != 0 {
+			trans.WriteString(NL)
+			trans.line = 0
+		}
+
+		for _, decl := range node.Decls {
+			switch decl.(type) {
+			case *ast.FuncDecl:
+				trans.getFunc(decl.(*ast.FuncDecl))
+
+			// godoc go/ast GenDecl
+			//  Tok    token.Token   // IMPORT, CONST, TYPE, VAR
+			//  Specs  []Spec
+			case *ast.GenDecl:
+				genDecl := decl.(*ast.GenDecl)
+
+				switch genDecl.Tok {
+				case token.IMPORT:
+					trans.getImport(genDecl.Specs)
+				case token.CONST:
+					trans.getConst(genDecl.TokPos, genDecl.Specs, true)
+				case token.TYPE:
+					trans.getType(genDecl.Specs, true)
+				case token.VAR:
+					trans.getVar(genDecl.Specs, true)
+				}
+
+			default:
+				trans.addError(decl.Pos(), "unsupported-declaration",
+					"declaration of kind %s", nodeKind(decl))
+			}
+		}
+	}
+
+	if trans.hasError {
+		return
 	}
 
 	// Export declarations in packages
-	if pkgName != "main" {
+	if isModule {
+		if pkgName != "main" {
+			trans.exportRest()
+		}
+	} else if pkgName != "main" {
 		if len(trans.exported) != 0 {
 			for i, v := range trans.exported {
 				if i == 0 {
 					trans.WriteString(NL+NL)
 				}
 
-				if !Bootstrap {
+				if !trans.opts.Bootstrap {
 					if i == 0 {
 						trans.WriteString(fmt.Sprintf("g.Export(%s,%s[%s",
 							pkgName, SP, v))
@@ -294,7 +526,7 @@ func Compile(filename string) error {
 						pkgName, v+SP, SP+v, NL))
 				}
 			}
-			if !Bootstrap {
+			if !trans.opts.Bootstrap {
 				trans.WriteString("]);")
 			}
 		} else {
@@ -303,48 +535,56 @@ func Compile(filename string) error {
 
 		trans.WriteString(NL + "})();")
 	}
+
+	if pkgName == "main" && trans.opts.Target == Node {
+		trans.WriteString(NL + NL + "main();")
+	}
 	trans.WriteString(NL)
+}
 
-	// === Write
-	baseFilename := strings.Replace(filename, path.Ext(filename), "", 1)
-	str := trans.String()
+// Returns the statement to load the module in "file" with name "name".
+func (trans *transform) importModule(name, file string) string {
+	if trans.opts.Format == CommonJS {
+		return fmt.Sprintf("var %s=%srequire(%q);", name+SP, SP, file)
+	}
+	return fmt.Sprintf("import * as %s from %q;", name, file)
+}
 
-	// Variables addressed
-	trans.replacePointers(&str)
+// Exports the names which have not been exported at their declaration,
+// in modules.
+func (trans *transform) exportRest() {
+	names := make([]string, 0)
 
-	// Version to debug
-	deb := strings.Replace(str, NL, "\n", -1)
-	deb = strings.Replace(deb, TAB, "\t", -1)
-	deb = strings.Replace(deb, SP, " ", -1)
+	for _, v := range trans.exported {
+		if !trans.exportedAt[v] {
+			names = append(names, v)
+		}
+	}
 
-	if err := ioutil.WriteFile(baseFilename+".js", []byte(deb), 0664); err != nil {
-		return err
+	if len(names) == 0 {
+		return
 	}
-/*
-	// Minimized version
-	min := strings.Replace(str, NL, "", -1)
-	min = strings.Replace(min, TAB, "", -1)
-	min = strings.Replace(min, SP, "", -1)
+	if trans.opts.Format == CommonJS {
+		trans.WriteString(fmt.Sprintf("%smodule.exports%s=%s{%s};",
+			NL+NL, SP, SP, SP+strings.Join(names, ","+SP)+SP))
+	} else {
+		trans.WriteString(fmt.Sprintf("%sexport%s{%s};",
+			NL+NL, SP, SP+strings.Join(names, ","+SP)+SP))
+	}
+}
 
-	if err := ioutil.WriteFile(baseFilename + ".min.js", []byte(min), 0664); err != nil {
-		return err
-	}*/
+// Returns the code translated, either minimized or to debug, and the points
+// mapped to the Go source.
+func (trans *transform) output(minimize bool) (string, []srcMapping) {
+	str := trans.String()
 
-	// Print warnings
-	if len(trans.warn) != 0 {
-		fmt.Fprintln(os.Stderr, " == Warnings\n")
+	// Version to debug
+	deb := strings.Replace(str, NL, "\n", -1)
+	deb = strings.Replace(deb, TAB, "\t", -1)
+	deb = strings.Replace(deb, SP, " ", -1)
 
-		for _, v := range trans.warn {
-			fmt.Fprintln(os.Stderr, v)
-		}
-		if len(trans.warn) == MaxMessage {
-			fmt.Fprintln(os.Stderr, "\n Too many warnings")
-		}
+	if minimize {
+		return minify(deb, trans.exported)
 	}
-/*
-for k, v := range trans.slices {
-	fmt.Println(k, v)
-}
-*/
-	return nil
+	return extractMarks(deb)
 }
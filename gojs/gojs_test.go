@@ -14,20 +14,35 @@
 
 package gojs
 
-import "testing"
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
 
 const (
 	DIR_PKG  = "../_pkg/"
 	DIR_TEST = "../_test/"
 )
 
-func init() {
-	MaxMessage = 100 // to show all errors
+// Options to compile the test files.
+var testOpts = Options{
+	MaxMessage: 100, // to show all errors
 
 	// In the test files, it is used functions "fmt.Print" to show errors.
-	for _, v := range []string{"", "ln", "f"} {
-		Function["fmt.Print" + v] = "alert"
-	}
+	Function: map[string]string{
+		"fmt.Print":   "alert",
+		"fmt.Println": "alert",
+		"fmt.Printf":  "alert",
+	},
 }
 
 func TestConst(t *testing.T)  { compile('t', "decl_const.go", t) }
@@ -35,76 +50,686 @@ func TestVar(t *testing.T)    { compile('t', "decl_var.go", t) }
 func TestStruct(t *testing.T) { compile('t', "decl_struct.go", t) }
 //func TestOp(t *testing.T)     { compile('t', "operator.go", t) }
 
-func TestPointer(t *testing.T)  { compile('t', "pointer.go", t) }
-func TestFunc(t *testing.T)     { compile('t', "func.go", t) }
-func TestCompo(t *testing.T)    { compile('t', "composite.go", t) }
-func TestSlice(t *testing.T)    { compile('t', "slice.go", t) }
-func TestMap(t *testing.T)      { compile('t', "map.go", t) }
-func TestFuncMore(t *testing.T) { compile('t', "func-more.go", t) }
-func TestMethod(t *testing.T)   { compile('t', "method.go", t) }
+func TestPointer(t *testing.T)   { compile('t', "pointer.go", t) }
+func TestFunc(t *testing.T)      { compile('t', "func.go", t) }
+func TestCompo(t *testing.T)     { compile('t', "composite.go", t) }
+func TestSlice(t *testing.T)     { compile('t', "slice.go", t) }
+func TestMap(t *testing.T)       { compile('t', "map.go", t) }
+func TestFuncMore(t *testing.T)  { compile('t', "func-more.go", t) }
+func TestMethod(t *testing.T)    { compile('t', "method.go", t) }
+func TestNumber(t *testing.T)    { compile('t', "number.go", t) }
+func TestInt64(t *testing.T)     { compile('t', "int64.go", t) }
+func TestComplex(t *testing.T)   { compile('t', "complex.go", t) }
+func TestString(t *testing.T)    { compile('t', "string.go", t) }
+func TestValue(t *testing.T)     { compile('t', "value.go", t) }
+func TestEqual(t *testing.T)     { compile('t', "equal.go", t) }
+func TestMapKey(t *testing.T)    { compile('t', "map_key.go", t) }
+func TestInterface(t *testing.T) { compile('t', "interface.go", t) }
+func TestEmbed(t *testing.T)     { compile('t', "embed.go", t) }
+func TestFuncType(t *testing.T)  { compile('t', "func_type.go", t) }
+func TestNamedType(t *testing.T) { compile('t', "named_type.go", t) }
 
 // == Warnings
-//
-// ../_test/control.go:44:2: 'default' clause above 'case' clause in switch statement
-func Example_control() { Compile(DIR_TEST + "control.go") }
+func Example_control() {
+	printDiag(Compile(DIR_TEST+"control.go", testOpts))
+	// Output:
+	// ../_test/control.go:44:2: 'default' clause above 'case' clause in switch statement [default-not-last]
+}
 
 // == Errors
-//
-// os: import from core library
-// ../_test/error_decl.go:13:10: complex128 type
-// ../_test/error_decl.go:14:10: complex128 type
-// ../_test/error_decl.go:15:10: complex128 type
-// ../_test/error_decl.go:16:10: complex128 type
-// ../_test/error_decl.go:18:6: built-in function complex()
-// ../_test/error_decl.go:23:17: complex64 type
-// ../_test/error_decl.go:24:13: complex64 type
-// ../_test/error_decl.go:25:11: complex64 type
-// ../_test/error_decl.go:30:14: complex128 type
-// ../_test/error_decl.go:31:9: complex128 type
-// ../_test/error_decl.go:32:12: complex128 type
-// ../_test/error_decl.go:37:16: complex64 type
-// ../_test/error_decl.go:38:23: complex64 type
-// ../_test/error_decl.go:39:11: complex64 type
-// ../_test/error_decl.go:48:12: channel type
-// ../_test/error_decl.go:49:12: channel type
-// ../_test/error_decl.go:50:7: channel operator
-// ../_test/error_decl.go:60:2: function type in struct
-// ../_test/error_decl.go:64:4: int64 type
-// ../_test/error_decl.go:65:2: anonymous field in struct
-// ../_test/error_decl.go:66:4: complex128 type
-func Example_decl() { Compile(DIR_TEST + "error_decl.go") }
+func Example_decl() {
+	printDiag(Compile(DIR_TEST+"error_decl.go", testOpts))
+	// Output:
+	// ../_test/error_decl.go:7:4: os: import from core library [unsupported-import]
+	// ../_test/error_decl.go:14:12: channel type [unsupported-channel]
+	// ../_test/error_decl.go:15:12: channel type [unsupported-channel]
+	// ../_test/error_decl.go:16:7: channel operator [unsupported-channel]
+	// ../_test/error_decl.go:37:10: receiver of kind IndexExpr [unsupported-method]
+	// ../_test/error_decl.go:40:2: embedded field of kind IndexExpr [unsupported-embedded]
+}
 
 // == Errors
-//
-// ../_test/error_stmt.go:6:13: channel type
-// ../_test/error_stmt.go:8:2: goroutine
-// ../_test/error_stmt.go:9:2: defer directive
-// ../_test/error_stmt.go:12:2: built-in function recover()
-// ../_test/error_stmt.go:18:1: use of label
-// ../_test/error_stmt.go:23:3: goto directive
-func Example_stmt() { Compile(DIR_TEST + "error_stmt.go") }
+func Example_const() {
+	printDiag(Compile(DIR_TEST+"error_const.go", testOpts))
+
+	// Output:
+	// ../_test/error_const.go:15:7: constant 9007199254740993 is not represented exactly by a JavaScript number [constant-precision]
+}
+
+func Example_expr() {
+	printDiag(Compile(DIR_TEST+"error_expr.go", testOpts))
+
+	// Output:
+	// ../_test/error_expr.go:7:6: built-in function append() [unsupported-append]
+	// ../_test/error_expr.go:8:2: built-in function copy() [unsupported-copy]
+	// ../_test/error_expr.go:10:11: built-in function new() of kind StructType [unsupported-new]
+	// ../_test/error_expr.go:12:9: index of slice which is not a literal [unsupported-slice]
+	// ../_test/error_expr.go:20:7: composite literal of kind StructType [unsupported-composite]
+	// ../_test/error_expr.go:21:7: address of a field or an element which is not a struct nor an array [unsupported-address]
+}
+
+func Example_stmt() {
+	printDiag(Compile(DIR_TEST+"error_stmt.go", testOpts))
+	// Output:
+	// ../_test/error_stmt.go:6:13: channel type [unsupported-channel]
+	// ../_test/error_stmt.go:8:2: goroutine [unsupported-goroutine]
+	// ../_test/error_stmt.go:9:2: defer directive [unsupported-defer]
+	// ../_test/error_stmt.go:12:2: built-in function recover() [unsupported-recover]
+	// ../_test/error_stmt.go:18:1: use of label [unsupported-label]
+	// ../_test/error_stmt.go:23:3: goto directive [unsupported-goto]
+}
+
+func Example_type() {
+	printDiag(Compile(DIR_TEST+"error_type.go", testOpts))
+
+	// Output:
+	// ../_test/error_type.go:7:2: "math" imported and not used [unused-import]
+	// ../_test/error_type.go:13:17: cannot use 1 (untyped int constant) as string value in variable declaration [type]
+	// ../_test/error_type.go:14:12: not enough arguments in call to sum [type]
+	// ../_test/error_type.go:15:7: undefined: undefined [type]
+	// ../_test/error_type.go:16:2: declared and not used: y [unused-variable]
+	// ../_test/error_type.go:21:18: cannot use 256 (untyped int constant) as byte value in constant declaration (overflows) [constant-overflow]
+	// ../_test/error_type.go:30:24: ambiguous selector both{}.x [type]
+}
+
+// A package split in several files.
+func TestPackage(t *testing.T) {
+	if _, err := CompilePackage(DIR_TEST+"multi", testOpts); err != nil {
+		t.Fatalf("expected parse package: %s", err)
+	}
+
+	// The package clause is in the first line, like the header.
+	js, err := ioutil.ReadFile(DIR_TEST + "multi/multi.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := HEADER + "\nvar multi = {};"; !bytes.HasPrefix(js, []byte(want)) {
+		t.Errorf("expected to start with %q, got:\n%s", want, js)
+	}
+}
+
+// The translation in memory.
+func TestTranslate(t *testing.T) {
+	src := `package main
+
+func main() {
+	println("Hello")
+}
+`
+	fset := token.NewFileSet()
+
+	node, err := parser.ParseFile(fset, "hello.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, minify := range []bool{false, true} {
+		js, diag := Translate(fset, []*ast.File{node}, Options{Minify: minify})
+		if len(diag) != 0 {
+			t.Fatalf("expected no diagnostics: %s", diag)
+		}
+
+		want := "function main() {\n\tconsole.log(\"Hello\\n\");\n}"
+		if minify {
+			want = `function main(){console.log("Hello\n")}`
+		}
+		if !strings.Contains(string(js), want) {
+			t.Errorf("minify=%v: expected to contain %q, got:\n%s", minify, want, js)
+		}
+	}
+
+	// Errors
+	node, _ = parser.ParseFile(fset, "defer.go", "package main\nfunc f() {}\nfunc main() { defer f() }", 0)
+
+	js, diag := Translate(fset, []*ast.File{node}, Options{})
+	if js != nil || len(diag) != 1 || diag[0].Code != "unsupported-defer" {
+		t.Errorf("expected an error for defer, got: %q", diag)
+	}
+	if diag[0].Pos.String() != "defer.go:3:15" {
+		t.Errorf("wrong position: %s", diag[0].Pos)
+	}
+
+	// No files
+	js, diag = Translate(fset, nil, Options{})
+	if js != nil || len(diag) != 1 || diag[0].Code != "no-files" {
+		t.Errorf("expected an error for no files, got: %q", diag)
+	}
+	if _, err := TranslateTo(new(bytes.Buffer), fset, nil, Options{}); err == nil {
+		t.Error("TranslateTo: expected an error for no files")
+	}
+	if _, _, diag = TranslateMap(fset, nil, Options{}, "x.js"); !diag.HasErrors() {
+		t.Error("TranslateMap: expected an error for no files")
+	}
+}
+
+// geomImporter imports the package "github.com/user/geom", used in the
+// modules of the tests.
+type geomImporter struct{}
+
+func (geomImporter) Import(path string) (*types.Package, error) {
+	if path != "github.com/user/geom" {
+		return nil, fmt.Errorf("can't find import: %q", path)
+	}
+
+	pkg := types.NewPackage(path, "geom")
+	pkg.Scope().Insert(types.NewVar(token.NoPos, pkg, "Scale", types.Typ[types.Float64]))
+
+	// type Meter float64; func (m Meter) String() string
+	meter := types.NewNamed(types.NewTypeName(token.NoPos, pkg, "Meter", nil), types.Typ[types.Float64], nil)
+	recv := types.NewVar(token.NoPos, pkg, "m", meter)
+	result := types.NewVar(token.NoPos, pkg, "", types.Typ[types.String])
+	meter.AddMethod(types.NewFunc(token.NoPos, pkg, "String",
+		types.NewSignatureType(recv, nil, nil, nil, types.NewTuple(result), false)))
+	pkg.Scope().Insert(meter.Obj())
+
+	pkg.MarkComplete()
+	return pkg, nil
+}
+
+func TestModule(t *testing.T) {
+	src := `package shape
+
+import (
+	"fmt"
+	"github.com/user/geom"
+)
+
+const Pi, Sides = 3.14, 0
+
+type Circle struct {
+	radius float64
+}
+
+func (c Circle) Area() float64 {
+	return c.radius * c.radius * Pi
+}
+
+func NewCircle(radius float64) Circle {
+	return Circle{radius}
+}
+
+var Total, count = 0, 0
+
+func scale(c Circle) float64 { return geom.Scale * c.radius }
+
+func show() { fmt.Print(Total) }
+`
+	fset := token.NewFileSet()
+
+	node, err := parser.ParseFile(fset, "shape.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	js, diag := Translate(fset, []*ast.File{node}, Options{Format: ESModule, Importer: geomImporter{}})
+	if diag.HasErrors() {
+		t.Fatal(diag)
+	}
+
+	for _, want := range []string{
+		HEADER + "\nimport * as g from \"./pkg.js\";",
+		"\nimport * as geom from \"./geom.js\";\n",
+		"\nexport const Pi = 3.14, Sides = 0;\n",
+		"\nexport function Circle(radius) {",
+		"\nCircle.prototype.Area = function() {",
+		"\nexport function NewCircle(radius) {",
+		"\nvar Total = 0, count = 0;\n",
+		"\nfunction scale(c) {",
+		"\n\nexport { Total };\n",
+	} {
+		if !strings.Contains(string(js), want) {
+			t.Errorf("expected to contain %q, got:\n%s", want, js)
+		}
+	}
+	if strings.Contains(string(js), "g.Export") || strings.Contains(string(js), "fmt.js") {
+		t.Errorf("expected no IIFE neither import of core library, got:\n%s", js)
+	}
+}
+
+func TestNode(t *testing.T) {
+	srcMain := `package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		os.Exit(2)
+	}
+	print(len(os.Args))
+	fmt.Println("arg:", os.Args[1])
+	fmt.Println()
+	println()
+}
+`
+	srcPkg := `package shape
+
+import "github.com/user/geom"
+
+const Pi = 3.14
+
+func Area(r float64) float64 { return r * r * Pi * geom.Scale }
+
+var count = 0
+`
+	opts := Options{Format: CommonJS, Target: Node, Importer: geomImporter{}}
+
+	for src, wants := range map[string][]string{
+		srcMain: {
+			HEADER + "\nvar g = require(\"./pkg.js\");",
+			"\tif (g.NewSlice(process.argv.map(g.Encode), 1).len < 2) {\n\t\tprocess.exit(2);",
+			"\tprocess.stdout.write(String(g.NewSlice(process.argv.map(g.Encode), 1).len));\n",
+			"\tprocess.stdout.write(g.Decode(\"arg: \" + g.NewSlice(process.argv.map(g.Encode), 1).f[1] + \"\\n\"));\n",
+			"\tprocess.stdout.write(\"\\n\");\n\tprocess.stdout.write(\"\\n\");\n}",
+			"}\n\nmain();\n",
+		},
+		srcPkg: {
+			"\nvar geom = require(\"./geom.js\");\n",
+			"\nconst Pi = 3.14;\n",
+			"\nfunction Area(r) {",
+			"\n\nmodule.exports = { Pi, Area };\n",
+		},
+	} {
+		fset := token.NewFileSet()
+
+		node, err := parser.ParseFile(fset, "node.go", src, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		js, diag := Translate(fset, []*ast.File{node}, opts)
+		if diag.HasErrors() {
+			t.Fatal(diag)
+		}
+
+		for _, want := range wants {
+			if !strings.Contains(string(js), want) {
+				t.Errorf("expected to contain %q, got:\n%s", want, js)
+			}
+		}
+	}
+
+	// The package "os" is only available in Node.js
+	fset := token.NewFileSet()
+	node, _ := parser.ParseFile(fset, "os.go", srcMain, 0)
+
+	if _, diag := Translate(fset, []*ast.File{node}, Options{}); !diag.HasErrors() ||
+		diag[0].Code != "unsupported-import" {
+		t.Errorf("expected an error importing \"os\", got: %q", diag)
+	}
+}
+
+// The methods and the descriptors of the named types which are not structs,
+// used from other package.
+func TestStaticExport(t *testing.T) {
+	srcPkg := `package geom
+
+type Meter float64
+
+func (m Meter) String() string { return "m" }
+func (m Meter) half() Meter    { return m / 2 }
+`
+	srcMain := `package main
+
+import "github.com/user/geom"
+
+type stringer interface{ String() string }
+
+func main() {
+	m := geom.Meter(3)
+	var s stringer = m
+	println(m.String(), s.String())
+}
+`
+	for _, tt := range []struct {
+		src    string
+		format Format
+		wants  []string
+	}{
+		{srcPkg, ESModule, []string{
+			"\nexport var Meter$type = g.Type(\"geom.Meter\"",
+			"\nexport function Meter$String(m) {",
+			"\nfunction Meter$half(m) {",
+		}},
+		{srcPkg, CommonJS, []string{
+			"\n\nmodule.exports = { Meter$type, Meter$String };\n",
+		}},
+		{srcMain, ESModule, []string{
+			"\tvar m = 3;\n",
+			"\tvar s = g.Box(m, geom.Meter$type);\n",
+			"geom.Meter$String(m)",
+		}},
+	} {
+		fset := token.NewFileSet()
+
+		node, err := parser.ParseFile(fset, "static.go", tt.src, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		js, diag := Translate(fset, []*ast.File{node}, Options{Format: tt.format, Importer: geomImporter{}})
+		if diag.HasErrors() {
+			t.Fatal(diag)
+		}
+
+		for _, want := range tt.wants {
+			if !strings.Contains(string(js), want) {
+				t.Errorf("expected to contain %q, got:\n%s", want, js)
+			}
+		}
+	}
+}
+
+// Compilations with different options at the same time.
+func TestConcurrent(t *testing.T) {
+	src := "package main\nimport \"fmt\"\nfunc main() { fmt.Println(\"Hello\") }\n"
+	fset := token.NewFileSet()
+
+	node, err := parser.ParseFile(fset, "hello.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]Options{
+		"alert(":                testOpts,
+		"document.write(":       {},
+		"process.stdout.write(": {Target: Node},
+		"log(":                  {Function: map[string]string{"fmt.Println": "log"}},
+	}
+	done := make(chan error)
+
+	for want, opts := range tests {
+		go func(want string, opts Options) {
+			for i := 0; i < 20; i++ {
+				js, _ := Translate(fset, []*ast.File{node}, opts)
+				if !strings.Contains(string(js), "{ "+want) {
+					done <- fmt.Errorf("expected to contain %q, got:\n%s", want, js)
+					return
+				}
+			}
+			done <- nil
+		}(want, opts)
+	}
+
+	for range tests {
+		if err := <-done; err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+// Decisions based on the types of the expressions, which are not variables.
+func TestTypes(t *testing.T) {
+	src := `package main
+
+type list struct {
+	items []int
+	index map[string]int
+}
+
+func get() []int { return []int{1, 2} }
+
+func main() {
+	l := list{}
+	n := len(l.items) + len(get())
+	x := l.items[0]
+	y := l.index["a"]
+
+	type celsius float64
+	var c celsius
+	var e error
+	println(n, x, y, c, e, l.items == nil, n == x)
+}
+`
+	fset := token.NewFileSet()
+
+	node, err := parser.ParseFile(fset, "types.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	js, diag := Translate(fset, []*ast.File{node}, Options{})
+	if diag.HasErrors() {
+		t.Fatal(diag)
+	}
+
+	for _, want := range []string{
+		"function get() { return g.NewSlice([1, 2], 0); }",
+		"\tvar n = l.items.len + get().len;\n",
+		"\tvar x = l.items.f[0];\n",
+		"\tvar y = (l.index || g.NilMap(0)).get(\"a\")[0];\n",
+		"\tvar c = 0;\n",
+		"\tvar e = undefined;\n",
+		" + l.items.isNil() + \" \" + (n === x) + ",
+	} {
+		if !strings.Contains(string(js), want) {
+			t.Errorf("expected to contain %q, got:\n%s", want, js)
+		}
+	}
+}
+
+func TestMinify(t *testing.T) {
+	src := `var pkg = {}; (function() {
+function add(value, delta) {
+	var total = value + delta;
+	if (total > max) {
+		return max;
+	}
+	return total;
+}
+function Sum(list) {
+	var sum = 0;
+	for (var i = 0; i < list.length; i++) { sum = add(sum, list[i]); }
+	return sum;
+}
+var max = 100;
+g.Export(pkg, [Sum]);
+})();
+`
+	want := HEADER + "\n" +
+		"var pkg={};(function(){function a(a,c){var d=a+c;if(d>b)return b;return d}\n" +
+		"function Sum(b){var c=0;for(var d=0;d<b.length;d++)c=a(c,b[d]);return c}\n" +
+		"var b=100;g.Export(pkg,[Sum])})();\n"
+
+	if got, _ := minify(src, []string{"Sum"}); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSourceMap(t *testing.T) {
+	src := `package main
+
+func main() {
+	x := 1
+	println(x)
+}
+`
+	fset := token.NewFileSet()
+
+	node, err := parser.ParseFile(fset, "/src/hello.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, minify := range []bool{false, true} {
+		js, smap, _ := TranslateMap(fset, []*ast.File{node}, Options{Minify: minify},
+			"/src/hello.js")
+
+		if !strings.HasSuffix(string(js), "//# sourceMappingURL=hello.js.map\n") {
+			t.Errorf("minify=%v: expected link to the source map, got:\n%s", minify, js)
+		}
+
+		want := `{"version":3,"file":"hello.js","sources":["hello.go"],"names":["main","x"],"mappings":`
+		if !strings.HasPrefix(string(smap), want) {
+			t.Errorf("minify=%v: got source map %s", minify, smap)
+		}
+	}
+
+	// In base 0: "func" at line 2 is mapped to the line 2, column 0 of the
+	// code generated; and the name "main", at column 5, to the column 9.
+	// Then, the statement at column 1 of the next line, and its name "x".
+	_, smap, _ := TranslateMap(fset, []*ast.File{node}, Options{}, "/src/hello.js")
+	if want := `"mappings":";;AAEA,SAAKA;CACJ,IAAAC;CACA"`; !strings.Contains(string(smap), want) {
+		t.Errorf("wrong mappings: %s", smap)
+	}
+}
+
+func TestVLQ(t *testing.T) {
+	for value, want := range map[int]string{0: "A", 1: "C", -1: "D", 15: "e", 16: "gB", -255: "/P"} {
+		var buf bytes.Buffer
+
+		if writeVLQ(&buf, value); buf.String() != want {
+			t.Errorf("%d: got %q, want %q", value, buf.String(), want)
+		}
+	}
+}
 
 // === Library
 //
 
-func TestPkg(t *testing.T) { compile('p', "pkg.go", t) }
+// The files of the gojs's package are compiled into "pkg.js".
+func TestPkg(t *testing.T) {
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0)
+
+	for _, name := range []string{"pkg.go", "map.go", "iface.go", "int64.go", "complex.go", "string.go"} {
+		file, err := parser.ParseFile(fset, DIR_PKG+name, nil, 0)
+		if err != nil {
+			t.Fatalf("expected parse file: %s", err)
+		}
+		files = append(files, file)
+	}
+
+	opts := testOpts
+	opts.Bootstrap = true
+
+	// A build for every format of module.
+	for format, dir := range runtimeDir {
+		opts.Format = format
+
+		if _, err := compileFiles(fset, files, DIR_PKG+dir+"pkg", opts); err != nil {
+			t.Fatalf("expected compile package: %s", err)
+		}
+	}
+}
+
+// Directory, into DIR_PKG, of the build of the gojs's package for every format.
+var runtimeDir = map[Format]string{
+	IIFE:     "",
+	ESModule: "esm/",
+	CommonJS: "cjs/",
+}
+
+// A program to run by Node.js, and its output.
+const (
+	nodeSrc = `package main
+
+import "fmt"
+
+type counter struct{ n int }
+
+func (c *counter) inc() { c.n++ }
+
+func main() {
+	sum := 0
+	for _, v := range []int{1, 2, 3} {
+		sum += v
+	}
+	m := map[string]int{"a": 1}
+	c := &counter{}
+	c.inc()
+	var big int64 = 1 << 40
+	var f float32 = 0.3
+	fmt.Println("sum:", sum, len(m), c.n, big/2, real(complex(1.5, 2)), f, "ñ")
+	fmt.Println()
+}
+`
+	nodeOut = "sum: 6 1 1 549755813888 1.5 0.3 ñ\n\n"
+)
+
+func TestESModuleRun(t *testing.T) {
+	if got := runNode(t, nodeSrc, ESModule); got != nodeOut {
+		t.Errorf("got %q, want %q", got, nodeOut)
+	}
+}
+
+func TestCommonJSRun(t *testing.T) {
+	if got := runNode(t, nodeSrc, CommonJS); got != nodeOut {
+		t.Errorf("got %q, want %q", got, nodeOut)
+	}
+}
 
 // * * *
 
 func compile(kind rune, filename string, t *testing.T) {
 	dir := ""
+	opts := testOpts
 
 	if kind == 't' {
 		dir = DIR_TEST
-		Bootstrap = false
 	} else if kind == 'p' {
 		dir = DIR_PKG
-		Bootstrap = true
+		opts.Bootstrap = true
 	} else {
 		panic("Wrong kind")
 	}
 
-	if err := Compile(dir + filename); err != nil {
+	if _, err := Compile(dir+filename, opts); err != nil {
 		t.Fatalf("expected parse file: %s", err)
 	}
 }
+
+// Prints the diagnostics, with their code.
+func printDiag(diag Diagnostics, err error) {
+	for _, d := range diag {
+		fmt.Printf("%s [%s]\n", d, d.Code)
+	}
+}
+
+// Runs through Node.js the program in "src", translated to the module
+// "format" and loading the build of the gojs's package for that format.
+// Returns its standard output.
+func runNode(t *testing.T, src string, format Format) string {
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node not found")
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "main.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	js, diag := Translate(fset, []*ast.File{file}, Options{Format: format, Target: Node})
+	if diag.HasErrors() {
+		t.Fatal(diag)
+	}
+
+	runtime, err := ioutil.ReadFile(DIR_PKG + runtimeDir[format] + "pkg.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkgType := "commonjs"
+	if format == ESModule {
+		pkgType = "module"
+	}
+
+	dir := t.TempDir()
+	for name, data := range map[string][]byte{
+		"main.js":      js,
+		"pkg.js":       runtime,
+		"package.json": []byte(`{"type": "` + pkgType + `"}`),
+	} {
+		if err = ioutil.WriteFile(filepath.Join(dir, name), data, 0664); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := exec.Command(node, filepath.Join(dir, "main.js")).CombinedOutput()
+	if err != nil {
+		t.Fatalf("%s\n%s", err, out)
+	}
+	return string(out)
+}
@@ -0,0 +1,311 @@
+// Copyright 2012  The "GoScript" Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gojs
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+/*
+## Interfaces
+
+A value of an interface is nil, "undefined", or a box "g.I" which stores the
+dynamic value and its type. Each type has a descriptor at run time, built by
+"g.Type" with the string of the type, the function which compares its values,
+and its methods; the named types declare it in a variable:
+
+	type point struct{ x, y int }  =>  ... var point$type = g.Type("main.point", function(x, y) { return x.eq(y); });
+	var s Shape = c                =>  var s = g.Box(c.clone(), circle$type);
+	c := s.(circle)                =>  var c = g.Assert(s, circle$type).clone();
+	c, ok := s.(circle)            =>  var _ = g.AssertOk(s, circle$type, new circle(0)), c = _[0], ok = _[1];
+
+The values are boxed wherever Go converts them to an interface: at assigning,
+passing arguments, returning, building composite literals, indexing a map,
+and comparing with an interface. The box has the methods of the dynamic type,
+which call the ones of the value, so the methods of an interface are called
+like any other. The methods of the named types which are not structs are
+functions, which are given to the descriptor:
+
+	type celsius float64  =>  var celsius$type = g.Type("main.celsius", undefined, ["String() string"], {String: celsius$String});
+
+The descriptors of the exported types are exported too; the ones of other
+packages are qualified, like "geom.Point$type".
+
+The interface types have a descriptor built by "g.Iface", with the methods
+which the dynamic types have to have. The type switch checks each type by
+"g.Is":
+
+	switch v := x.(type) {  =>  var _x1 = x; switch (true) {
+	case int:               =>  case g.Is(_x1, g.Type("int")): var v = _x1.v;
+	case nil:               =>  case _x1 === undefined: var v = _x1;
+
+The descriptors are unique for the string of their type, so the local types
+with the same name in different functions are not distinguished.
+*/
+
+// Returns the string of the type, like it is written in Go.
+func typeString(typ types.Type) string {
+	return types.TypeString(typ, func(p *types.Package) string { return p.Name() })
+}
+
+// Reports whether a value of type "from" has to be boxed to assign it to a
+// value of type "to": an interface, whereas "from" is not.
+func isBoxed(to, from types.Type) bool {
+	if to == nil || from == nil || from == types.Typ[types.UntypedNil] {
+		return false
+	}
+	return types.IsInterface(to) && !types.IsInterface(from)
+}
+
+// Returns the value "js" of type "typ" stored in an interface.
+func (tr *transform) box(typ types.Type, js string) string {
+	return "g.Box(" + js + "," + SP + tr.typeDesc(typ) + ")"
+}
+
+// Returns the expression "js", which is the translation of "expr", assigned
+// to a value of type "to": copied if it is a shared value, and boxed if "to"
+// is an interface.
+func (tr *transform) valueTo(to types.Type, expr ast.Expr, js string, prec int) string {
+	js = tr.copyValue(expr, js, prec)
+
+	if from := tr.typeOf(expr); isBoxed(to, from) {
+		return tr.box(from, js)
+	}
+	return js
+}
+
+// Reports whether the expression has to be boxed to assign it to a value of
+// type "to".
+func (tr *transform) needsBox(to types.Type, expr ast.Expr) bool {
+	return isBoxed(to, tr.typeOf(expr))
+}
+
+// == Descriptors
+//
+
+// Returns the descriptor of the type: the variable declared for the named
+// types of the translated packages, else its construction.
+func (tr *transform) typeDesc(typ types.Type) string {
+	if named, ok := typ.(*types.Named); ok {
+		if pkg, ok := tr.qualifier(named.Obj().Pkg()); ok {
+			return pkg + named.Obj().Name() + "$type"
+		}
+	}
+	return tr.newTypeDesc(typ)
+}
+
+// Returns the construction of the descriptor of the type.
+func (tr *transform) newTypeDesc(typ types.Type) string {
+	str := strconv.Quote(typeString(typ))
+	methods := tr.methodsOf(typ)
+
+	if types.IsInterface(typ) {
+		return "g.Iface(" + str + "," + SP + methods + ")"
+	}
+
+	eq := ""
+	if !types.Comparable(typ) {
+		eq = "g.Uncomparable"
+	} else if js, _ := tr.equalOf(typ, "x", "y", 0, 0, false); js != "x"+SP+"==="+SP+"y" {
+		eq = fmt.Sprintf("function(x,%sy)%s{%sreturn %s;%s}", SP, SP, SP, js, SP)
+	}
+
+	if methods != "[]" {
+		if eq == "" {
+			eq = "undefined"
+		}
+		if funcs := tr.funcsOf(typ); funcs != "" {
+			return "g.Type(" + str + "," + SP + eq + "," + SP + methods + "," + SP + funcs + ")"
+		}
+		return "g.Type(" + str + "," + SP + eq + "," + SP + methods + ")"
+	}
+	return "g.Type(" + str + optionalArg(eq) + ")"
+}
+
+// Returns the array with the methods of the type, like "Area() float64".
+func (tr *transform) methodsOf(typ types.Type) string {
+	mset := types.NewMethodSet(typ)
+	methods := ""
+
+	for i := 0; i < mset.Len(); i++ {
+		m := mset.At(i)
+		sig := strings.TrimPrefix(typeString(m.Type()), "func")
+
+		if i != 0 {
+			methods += "," + SP
+		}
+		methods += strconv.Quote(m.Obj().Name() + sig)
+	}
+	return "[" + methods + "]"
+}
+
+// Returns the object with the functions which implement the methods of the
+// type by name, like "{String: celsius$String}", if it is a named type which is
+// not a struct, or a pointer to it; else an empty string.
+func (tr *transform) funcsOf(typ types.Type) string {
+	mset := types.NewMethodSet(typ)
+	funcs := ""
+
+	for i := 0; i < mset.Len(); i++ {
+		m := mset.At(i)
+		fun := tr.staticMethod(m.Obj())
+		if fun == "" || len(m.Index()) != 1 {
+			return ""
+		}
+
+		// A pointer stores the value, unless it is a named array.
+		if p, ok := typ.(*types.Pointer); ok && !isComposite(p.Elem()) {
+			sig := m.Obj().Type().(*types.Signature)
+			if _, ok := sig.Recv().Type().(*types.Pointer); !ok {
+				fun = "g.Deref(" + fun + ")"
+			}
+		}
+
+		if i != 0 {
+			funcs += "," + SP
+		}
+		funcs += m.Obj().Name() + ":" + SP + fun
+	}
+	return "{" + funcs + "}"
+}
+
+// Returns the declaration of the descriptor of the named type declared with
+// the name "name", exported like the type if it "isGlobal"; or an empty string
+// if its type is unknown.
+func (tr *transform) typeDecl(name *ast.Ident, isGlobal bool) string {
+	if tr.info == nil {
+		return ""
+	}
+	obj, ok := tr.info.Defs[name]
+	if !ok || obj == nil {
+		return ""
+	}
+
+	desc := &ast.Ident{NamePos: name.Pos(), Name: name.Name + "$type"}
+	export := ""
+	if isGlobal {
+		tr.addIfExported(desc)
+		export = tr.exportAt(desc)
+	}
+	return fmt.Sprintf("%svar %s%s=%s;", export, desc.Name, SP, SP+tr.newTypeDesc(obj.Type()))
+}
+
+// == Assertions
+//
+
+// Writes the type assertion "x.(T)"; with the form "v, ok := x.(T)" if
+// "commaOk", which returns the value and whether the assertion holds.
+func (e *expression) writeTypeAssert(assert *ast.TypeAssertExpr, commaOk bool) {
+	typ := e.tr.typeOf(assert.Type)
+	if typ == nil {
+		e.tr.addError(assert.Pos(), "unsupported-expression",
+			"type assertion of unknown type")
+		e.hasError = true
+		return
+	}
+	x := e.tr.getExpression(assert.X).String()
+	desc := e.tr.typeDesc(typ)
+
+	if !commaOk {
+		e.WriteString(fmt.Sprintf("g.Assert(%s,%s)", x, SP+desc))
+		return
+	}
+
+	zero, _ := e.tr.zeroOf(true, typ)
+	if zero == "" { // map
+		zero = "undefined"
+	}
+	e.WriteString(fmt.Sprintf("g.AssertOk(%s,%s,%s)", x, SP+desc, SP+zero))
+}
+
+// Writes the type switch, whose clauses check the type of the value stored
+// in a variable.
+func (tr *transform) writeTypeSwitch(stmt *ast.TypeSwitchStmt) {
+	var assert *ast.TypeAssertExpr
+
+	switch t := stmt.Assign.(type) {
+	case *ast.AssignStmt: // v := x.(type)
+		assert = t.Rhs[0].(*ast.TypeAssertExpr)
+	case *ast.ExprStmt:
+		assert = t.X.(*ast.TypeAssertExpr)
+	}
+
+	if stmt.Init != nil {
+		tr.getStatement(stmt.Init)
+		tr.WriteString(SP)
+	}
+
+	x := fmt.Sprintf("_x%d", tr.blockId)
+	tr.WriteString(fmt.Sprintf("var %s;%sswitch%s(true)%s",
+		x+SP+"="+SP+tr.getExpression(assert.X).String(), SP, SP, SP))
+
+	lenCase, iCase, typeSwitch := tr.lenCase, tr.iCase, tr.typeSwitch
+	tr.lenCase = len(stmt.Body.List)
+	tr.iCase = 0
+	tr.typeSwitch = x
+
+	tr.getStatement(stmt.Body)
+
+	tr.lenCase, tr.iCase, tr.typeSwitch = lenCase, iCase, typeSwitch
+}
+
+// Writes the cases of a clause of a type switch, and the declaration of the
+// variable of the switch, if any.
+func (tr *transform) writeTypeCase(clause *ast.CaseClause) {
+	x := tr.typeSwitch
+	var typ types.Type // type of the variable
+
+	for i, expr := range clause.List {
+		if i != 0 {
+			tr.WriteString(SP)
+		}
+		t := tr.typeOf(expr)
+
+		if t == types.Typ[types.UntypedNil] {
+			tr.WriteString(fmt.Sprintf("case %s:", x+SP+"==="+SP+"undefined"))
+			continue
+		}
+		tr.WriteString(fmt.Sprintf("case g.Is(%s,%s):", x, SP+tr.typeDesc(t)))
+
+		if len(clause.List) == 1 {
+			typ = t
+		}
+	}
+	if clause.List == nil {
+		tr.WriteString("default:")
+	}
+
+	if tr.info == nil {
+		return
+	}
+	obj, ok := tr.info.Implicits[clause]
+	if !ok || obj == nil {
+		return
+	}
+
+	// A clause with a type which is not an interface gets the dynamic value.
+	value := x
+	if typ != nil && !types.IsInterface(typ) {
+		value = tr.cloneOf(typ, x+".v", 0)
+	}
+	if tr.addressed[obj] {
+		value = "{p:" + value + "}"
+	}
+	tr.WriteString(fmt.Sprintf("%svar %s;", SP, obj.Name()+SP+"="+SP+value))
+}
@@ -17,14 +17,18 @@ package gojs
 import (
 	"fmt"
 	"go/ast"
+	exact "go/constant"
+	"go/types"
+	"path"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
-var validImport = []string{"fmt", "math", "rand"}
+var validImport = []string{"fmt", "math", "math/cmplx", "math/rand"}
 
 // Constants to transform.
-var Constant = map[string]string{
+var constant = map[string]string{
 	"math.E":      "Math.E",
 	"math.Ln2":    "Math.LN2",
 	"math.Log2E":  "Math.LOG2E",
@@ -35,7 +39,7 @@ var Constant = map[string]string{
 }
 
 // Functions that can be transformed since JavaScript has an equivalent one.
-var Function = map[string]string{
+var function = map[string]string{
 	"fmt.Print":   "document.write",
 	"fmt.Println": "document.write",
 	"fmt.Printf":  "document.write",
@@ -63,6 +67,75 @@ var Function = map[string]string{
 
 	"rand.Float32": "Math.random",
 	"rand.Float64": "Math.random",
+
+	"cmplx.Abs":   "g.CmplxAbs",
+	"cmplx.Conj":  "g.CmplxConj",
+	"cmplx.Cos":   "g.CmplxCos",
+	"cmplx.Exp":   "g.CmplxExp",
+	"cmplx.Inf":   "g.CmplxInf",
+	"cmplx.IsInf": "g.CmplxIsInf",
+	"cmplx.IsNaN": "g.CmplxIsNaN",
+	"cmplx.Log":   "g.CmplxLog",
+	"cmplx.NaN":   "g.CmplxNaN",
+	"cmplx.Phase": "g.CmplxPhase",
+	"cmplx.Polar": "g.CmplxPolar",
+	"cmplx.Pow":   "g.CmplxPow",
+	"cmplx.Rect":  "g.CmplxRect",
+	"cmplx.Sin":   "g.CmplxSin",
+	"cmplx.Sqrt":  "g.CmplxSqrt",
+}
+
+// Packages of the core library available in Node.js.
+var nodeImport = []string{"os"}
+
+// Constants to transform in Node.js, which replace the ones in constant.
+var nodeConstant = map[string]string{
+	// without the path of "node"; the arguments are encoded to UTF-8
+	"os.Args": "g.NewSlice(process.argv.map(g.Encode)," + SP + "1)",
+}
+
+// Functions to transform in Node.js, which replace the ones in function.
+var nodeFunction = map[string]string{
+	"fmt.Print":   "process.stdout.write",
+	"fmt.Println": "process.stdout.write",
+	"fmt.Printf":  "process.stdout.write",
+
+	"os.Exit": "process.exit",
+}
+
+// Reports whether "pkg" is a package of the core library that can be
+// transformed.
+func (tr *transform) isLibrary(pkg string) bool {
+	list := validImport
+	if tr.opts.Target == Node {
+		list = append(nodeImport, validImport...)
+	}
+
+	// The path of the import, or the name of the package used by a selector.
+	for _, v := range list {
+		if v == pkg || path.Base(v) == pkg {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the equivalent in JavaScript of a constant or function of the core
+// library. The mappings in the options have priority over the ones of the
+// target.
+func (tr *transform) libraryName(goName string) (jsName string, ok bool) {
+	maps := []map[string]string{tr.opts.Function, tr.opts.Constant}
+	if tr.opts.Target == Node {
+		maps = append(maps, nodeFunction, nodeConstant)
+	}
+	maps = append(maps, function, constant)
+
+	for _, m := range maps {
+		if jsName, ok = m[goName]; ok {
+			return
+		}
+	}
+	return
 }
 
 // Imports
@@ -83,48 +156,154 @@ func (tr *transform) getImport(spec []ast.Spec) {
 
 		// Core library
 		if !strings.Contains(path, ".") {
-			found := false
-			for _, v := range validImport {
-				if v == path {
-					found = true
-					break
-				}
+			if !tr.isLibrary(path) {
+				tr.addError(iSpec.Path.Pos(), "unsupported-import",
+					"%s: import from core library", path)
 			}
+			continue
+		}
+
+		// Packages of GoScript, placed in the same directory
+		if tr.opts.Format != IIFE {
+			name := path[strings.LastIndex(path, "/")+1:]
+			file := fmt.Sprintf("./%s.js", name)
 
-			if !found {
-				tr.addError("%s: import from core library", path)
+			tr.addLine(iSpec.Pos())
+			if iSpec.Name == nil {
+				tr.WriteString(tr.importModule(name, file))
 				continue
 			}
+
+			switch iSpec.Name.Name {
+			case BLANK:
+				if tr.opts.Format == CommonJS {
+					tr.WriteString(fmt.Sprintf("require(%q);", file))
+				} else {
+					tr.WriteString(fmt.Sprintf("import %q;", file))
+				}
+			case ".":
+				tr.addError(iSpec.Name.Pos(), "unsupported-import",
+					"%s: import into the file block", path)
+			default:
+				tr.WriteString(tr.importModule(iSpec.Name.Name, file))
+			}
+		}
+	}
+}
+
+// Returns the local names of the packages imported by the files, by path.
+func importNames(files []*ast.File, info *types.Info) map[string]string {
+	names := make(map[string]string)
+
+	for _, f := range files {
+		for _, iSpec := range f.Imports {
+			obj := info.Implicits[iSpec]
+			if iSpec.Name != nil {
+				obj = info.Defs[iSpec.Name]
+			}
+			if pkg, ok := obj.(*types.PkgName); ok {
+				names[pkg.Imported().Path()] = pkg.Name()
+			}
 		}
+	}
+	return names
+}
 
-		//import objectName.*;
-		//fmt.Println(iSpec.Name, pathDir)
+// Returns the prefix of the names declared in the package "pkg", like
+// "geom."; it is empty for the package translated. It reports false if the
+// package is not translated, like the core library.
+func (tr *transform) qualifier(pkg *types.Package) (string, bool) {
+	switch {
+	case pkg == nil || !strings.Contains(pkg.Path(), "."):
+		return "", pkg != nil && pkg.Path() == tr.pkgName
+	case pkg.Path() == tr.pkgName:
+		return "", true
+	}
+	if name, ok := tr.pkgNames[pkg.Path()]; ok {
+		return name + ".", true
 	}
+	return pkg.Name() + ".", true
 }
 
 // Returns the arguments of a Go function, formatted for JS.
-func (tr *transform) GetArgs(funcName string, args []ast.Expr) string {
+func (tr *transform) GetArgs(funcName string, call *ast.CallExpr) string {
 	var jsArgs string
+	args := call.Args
 
 	switch funcName {
 	case "print", "fmt.Print", "fmt.Sprint":
 		jsArgs = tr.joinArgsPrint(args, false)
+
+		if funcName != "fmt.Sprint" && tr.isText(args) {
+			jsArgs = "g.Decode(" + jsArgs + ")"
+		} else if tr.opts.Target == Node && funcName != "fmt.Sprint" {
+			// The stream of Node.js only writes strings.
+			jsArgs = "String(" + jsArgs + ")"
+		}
 	case "println", "fmt.Println":
 		jsArgs = tr.joinArgsPrint(args, true)
+
+		// The stream of Node.js has to write something, at least the line.
+		if len(args) == 0 && tr.opts.Target == Node {
+			jsArgs = "\"\\n\""
+		} else if tr.isText(args) {
+			jsArgs = "g.Decode(" + jsArgs + ")"
+		}
 	case "fmt.Printf", "fmt.Sprintf":
 		jsArgs = tr.joinArgsPrintf(args)
-	default:
-		for i, v := range args {
-			if i != 0 {
-				jsArgs += "," + SP
-			}
-			jsArgs += tr.getExpression(v).String()
+
+		if funcName != "fmt.Sprintf" && tr.isText(args) {
+			jsArgs = "g.Decode(" + jsArgs + ")"
 		}
+	default:
+		jsArgs = tr.joinArgs(call)
 	}
 
 	return jsArgs
 }
 
+// Returns the arguments of a call, boxed if their parameter is an interface.
+func (tr *transform) joinArgs(call *ast.CallExpr) string {
+	var jsArgs string
+	sig, _ := tr.typeOf(call.Fun).(*types.Signature)
+
+	for i, v := range call.Args {
+		if i != 0 {
+			jsArgs += "," + SP
+		}
+		x := tr.getExpression(v)
+
+		if to := paramType(sig, i, call.Ellipsis.IsValid()); tr.needsBox(to, v) {
+			jsArgs += tr.valueTo(to, v, x.String(), x.prec)
+		} else {
+			jsArgs += x.String()
+		}
+	}
+	return jsArgs
+}
+
+// Returns the type of the parameter which receives the argument "i" in a call
+// to a function of signature "sig"; or nil if it is unknown. The arguments of
+// a variadic parameter have the type of its elements, unless "hasEllipsis".
+func paramType(sig *types.Signature, i int, hasEllipsis bool) types.Type {
+	if sig == nil {
+		return nil
+	}
+	params := sig.Params()
+
+	if sig.Variadic() && i >= params.Len()-1 {
+		last := params.At(params.Len() - 1).Type()
+		if hasEllipsis {
+			return last
+		}
+		return last.(*types.Slice).Elem()
+	}
+	if i < params.Len() {
+		return params.At(i).Type()
+	}
+	return nil
+}
+
 //
 // === Utility
 
@@ -144,7 +323,7 @@ func (tr *transform) joinArgsPrint(args []ast.Expr, addLine bool) string {
 	}
 
 	for i, v := range args {
-		expr := tr.getExpression(v).String()
+		expr := tr.printArg(v)
 
 		if i != 0 {
 			jsArgs += SP + "+" + SP + expr
@@ -164,6 +343,60 @@ func (tr *transform) joinArgsPrint(args []ast.Expr, addLine bool) string {
 	return jsArgs
 }
 
+// Reports whether the arguments to print could have bytes out of ASCII, so the
+// text has to be decoded from UTF-8. The numbers, the booleans and the
+// constant strings in ASCII are written like they are.
+func (tr *transform) isText(args []ast.Expr) bool {
+	if tr.info == nil {
+		return false
+	}
+
+	for _, arg := range args {
+		tv, ok := tr.info.Types[arg]
+		if !ok {
+			return true
+		}
+		if tv.Value != nil && tv.Value.Kind() == exact.String {
+			s := exact.StringVal(tv.Value)
+			for i := 0; i < len(s); i++ {
+				if s[i] >= utf8.RuneSelf {
+					return true
+				}
+			}
+			continue
+		}
+		if b, ok := tv.Type.Underlying().(*types.Basic); !ok ||
+			b.Info()&(types.IsNumeric|types.IsBoolean) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns an argument to print, which is concatenated to strings.
+func (tr *transform) printArg(arg ast.Expr) string {
+	e := tr.getExpression(arg)
+
+	// The concatenation of strings is associative.
+	if t := tr.typeOf(arg); t != nil {
+		if b, ok := t.Underlying().(*types.Basic); ok && b.Info()&types.IsString != 0 {
+			return e.String()
+		}
+		// The shortest digits of a float32 are less than the ones of its
+		// value at 64 bits.
+		if b := numberOf(t); b != nil && b.Kind() == types.Float32 {
+			return fmt.Sprintf("g.FormatFloat(%s,%s32)", e, SP)
+		}
+		if b := numberOf(t); isObject(b) {
+			if b.Kind() == types.Complex64 {
+				return paren(e.String(), e.prec, 11) + ".toString(32)"
+			}
+			return paren(e.String(), e.prec, 11) + ".toString()"
+		}
+	}
+	return paren(e.String(), e.prec, jsPrecedence["+"])
+}
+
 // Matches verbs for "fmt.Printf"
 // http://golang.org/pkg/fmt/
 var (
@@ -194,7 +427,7 @@ func (tr *transform) joinArgsPrintf(args []ast.Expr) string {
 		if i != 0 {
 			result += fmt.Sprintf("%s+%s", SP, SP+`"`)
 		}
-		result += fmt.Sprintf("%s+%s", values[i]+`"`+SP, SP+tr.getExpression(v).String())
+		result += fmt.Sprintf("%s+%s", values[i]+`"`+SP, SP+tr.printArg(v))
 	}
 	result += fmt.Sprintf("%s+%s", SP, SP+`"`+values[len(values)-1])
 
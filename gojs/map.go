@@ -0,0 +1,202 @@
+// Copyright 2012  The "GoScript" Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gojs
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+)
+
+/*
+## Maps
+
+A map is an object "g.M", which stores its entries in a Map of JavaScript by
+the hash of their keys. The keys which JavaScript compares like Go, the basic
+values and the pointers, are their own hash; for the rest of types, the map is
+built with a function which hashes the keys, derived from their type:
+
+	m := map[string]int{"a": 1}  =>  var m = g.NewMap(0, [["a", 1]]);
+	make(map[point]bool)         =>  g.NewMap(false, [], function(k) { return g.Hash([k.x, k.y]); })
+
+	m[k] = v                     =>  m.entry(k)[1] = v;
+	v, ok := m[k]                =>  var _ = m.get(k), v = _[0], ok = _[1];
+	len(m), delete(m, k)         =>  m.len(), m.delete(k)
+
+A nil map is "undefined", which is read like an empty map with the zero value
+of its values: "(m || g.NilMap(0)).get(k)".
+
+The parts of a struct or an array are hashed in order; the pointers, channels
+and maps by an identifier of the object, and the interfaces by their dynamic
+type and value through "g.Key".
+*/
+
+// Returns the construction of a map of type "typ", with the entries "kv"; an
+// array of pairs of key and value, or an empty string.
+func (tr *transform) newMap(typ types.Type, kv string) string {
+	m, ok := typ.Underlying().(*types.Map)
+	if !ok {
+		return "g.NewMap(undefined" + optionalArg(kv) + ")"
+	}
+
+	hash := tr.hashFunc(m.Key())
+	if hash != "" && kv == "" {
+		kv = "[]"
+	}
+	return "g.NewMap(" + tr.mapZero(m) + optionalArg(kv) + optionalArg(hash) + ")"
+}
+
+// Returns the zero value of the values of the map "m".
+func (tr *transform) mapZero(m *types.Map) string {
+	zero, _ := tr.zeroOf(true, m.Elem())
+	if zero == "" { // map
+		zero = "undefined"
+	}
+	return zero
+}
+
+// Returns the map "js", the translation of "expr", whose operator at the top
+// has precedence "prec"; to be read, so a nil map is like an empty one.
+func (tr *transform) readMap(expr ast.Expr, js string, prec int) string {
+	t := tr.typeOf(expr)
+	if t == nil || isNewMap(expr) {
+		return js
+	}
+	m, ok := t.Underlying().(*types.Map)
+	if !ok {
+		return js
+	}
+	p := jsPrecedence["||"]
+	return "(" + paren(js, prec, p-1) + SP + "||" + SP + "g.NilMap(" + tr.mapZero(m) + "))"
+}
+
+// Reports whether the expression builds a map, which is not nil.
+func isNewMap(expr ast.Expr) bool {
+	switch t := ast.Unparen(expr).(type) {
+	case *ast.CompositeLit:
+		return true
+	case *ast.CallExpr:
+		ident, ok := ast.Unparen(t.Fun).(*ast.Ident)
+		return ok && ident.Name == "make"
+	}
+	return false
+}
+
+// Returns the function which hashes the keys of type "typ"; or an empty
+// string if the keys are their own hash.
+func (tr *transform) hashFunc(typ types.Type) string {
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		if !isObject(t) {
+			return ""
+		}
+	case *types.Pointer, *types.Chan:
+		return ""
+	}
+	return fmt.Sprintf("function(k)%s{%sreturn g.Hash(%s);%s}",
+		SP, SP, tr.hashOf(typ, "k"), SP)
+}
+
+// Returns the parts of the key "js" of type "typ", to be hashed.
+func (tr *transform) hashOf(typ types.Type, js string) string {
+	switch t := typ.Underlying().(type) {
+	case *types.Basic: // the numbers of 64 bits and the complex are stringified
+		return js
+	case *types.Struct:
+		parts := ""
+		for i := 0; i < t.NumFields(); i++ {
+			f := t.Field(i)
+			if f.Name() == "_" { // the blank fields are not compared
+				continue
+			}
+			if parts != "" {
+				parts += "," + SP
+			}
+			parts += tr.hashOf(f.Type(), js+"."+f.Name())
+		}
+		return "[" + parts + "]"
+	case *types.Array:
+		if _, ok := t.Elem().Underlying().(*types.Basic); ok {
+			return js
+		}
+		return fmt.Sprintf("%s.map(function(v)%s{%sreturn %s;%s})",
+			js, SP, SP, tr.hashOf(t.Elem(), "v"), SP)
+	case *types.Interface:
+		return "g.Key(" + js + ")"
+	}
+	// The pointers, channels and maps are compared by identity.
+	return "g.Id(" + js + ")"
+}
+
+// Returns the argument "arg" preceded by a comma, if it is not empty.
+func optionalArg(arg string) string {
+	if arg == "" {
+		return ""
+	}
+	return "," + SP + arg
+}
+
+// Writes the composite literal of a map.
+func (e *expression) writeMap(lit *ast.CompositeLit) {
+	kv := e.tr.newExpression(nil)
+	kv.inMap = true
+
+	kv.WriteString("[")
+	kv.writeElts(lit)
+	kv.WriteString("]")
+
+	if t := e.tr.typeOf(lit); t != nil {
+		e.WriteString(e.tr.newMap(t, kv.String()))
+	} else {
+		e.WriteString("g.NewMap(undefined," + SP + kv.String() + ")")
+	}
+}
+
+// Returns the entry [key, value] of a composite literal of a map of type "m".
+// The key is stored, so it is copied.
+func (tr *transform) mapEntry(m *types.Map, kv *ast.KeyValueExpr) string {
+	key := tr.getExpression(kv.Key)
+	value := tr.getExpression(kv.Value)
+
+	return "[" + tr.valueTo(m.Key(), kv.Key, key.String(), key.prec) + "," + SP +
+		tr.valueTo(m.Elem(), kv.Value, value.String(), value.prec) + "]"
+}
+
+// Writes the value of a map for a key; or its entry, if it is assigned.
+func (e *expression) writeMapIndex(index *ast.IndexExpr) {
+	x := e.tr.getExpression(index.X)
+	key := e.tr.getExpression(index.Index)
+	m := paren(x.String(), x.prec, 11)
+	e.mapName = m
+
+	var keyType types.Type
+	if t := e.tr.typeOf(index.X); t != nil {
+		keyType = t.Underlying().(*types.Map).Key()
+	}
+
+	if e.isTarget {
+		// The key is stored.
+		e.WriteString(m + ".entry(" + e.tr.valueTo(keyType, index.Index, key.String(), key.prec) + ")[1]")
+		return
+	}
+
+	m = e.tr.readMap(index.X, m, 0)
+
+	if e.tr.needsBox(keyType, index.Index) {
+		e.WriteString(m + ".get(" + e.tr.box(e.tr.typeOf(index.Index), key.String()) + ")[0]")
+	} else {
+		e.WriteString(m + ".get(" + key.String() + ")[0]")
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2012  The "GoScript" Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gojs
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+)
+
+/*
+## Methods
+
+The methods of a type "T" and of "*T" are in the prototype of "T", so they are
+called like the ones of JavaScript. A pointer to a struct or an array is the
+value pointed, which is an object, so the methods with a pointer receiver
+change it, and the operators "&" and "*" are not needed to call them:
+
+	func (r *rect) scale(f int)  =>  rect.prototype.scale = function(f) {
+	r.scale(2)                   =>  r.scale(2)
+	p := &r                      =>  var p = r;
+
+The receiver is "this", but a value receiver is copied if the method could
+modify it. A method value binds its receiver, which is copied if it is a
+value, and a method expression gets the receiver like the first argument:
+
+	f := r.area                  =>  var f = g.Bind(r.clone(), "area");
+	f := rect.area               =>  var f = g.MethodExpr("area");
+
+The values of the named types which are not structs are not wrapped, so their
+methods are functions named like the type and the method, which get the
+receiver like the first argument. Their values are boxed only when they are
+stored in an interface, whose box calls those functions:
+
+	func (c celsius) String() string  =>  function celsius$String(c) {
+	c.String()                        =>  celsius$String(c)
+	f := c.String                     =>  var f = celsius$String.bind(undefined, c);
+	f := celsius.String               =>  var f = celsius$String;
+
+The functions of the exported methods of the exported types are exported, so
+they are called from other packages like "temp.Celsius$String(c)".
+*/
+
+// Writes the method value or the method expression of the selector, if it is
+// not called; and reports whether it was written.
+func (e *expression) writeMethodValue(sel *ast.SelectorExpr, isCallee bool) bool {
+	if e.tr.info == nil {
+		return false
+	}
+	s, ok := e.tr.info.Selections[sel]
+	if !ok {
+		return false
+	}
+	name := strconv.Quote(sel.Sel.Name)
+	fun := e.tr.staticMethod(s.Obj())
+
+	switch {
+	case s.Kind() == types.MethodExpr && fun != "":
+		// A method of "T" used like one of "*T" gets the value pointed.
+		sig := s.Obj().Type().(*types.Signature)
+		_, isPtrRecv := sig.Recv().Type().(*types.Pointer)
+		_, isPtrX := s.Recv().(*types.Pointer)
+
+		if isPtrX && !isPtrRecv && !isComposite(s.Recv().(*types.Pointer).Elem()) {
+			fun = "g.Deref(" + fun + ")"
+		}
+		e.WriteString(fun)
+	case s.Kind() == types.MethodVal && !isCallee && fun != "":
+		e.WriteString(fmt.Sprintf("%s.bind(undefined,%s)", fun, SP+e.tr.staticRecv(sel, s, true)))
+	case s.Kind() == types.MethodExpr:
+		e.WriteString("g.MethodExpr(" + name + ")")
+	case s.Kind() == types.MethodVal && !isCallee:
+		x := e.tr.getExpression(sel.X)
+		recv := x.String()
+
+		// The receiver is copied at evaluating the method value.
+		sig := s.Obj().Type().(*types.Signature)
+		if _, ok := sig.Recv().Type().(*types.Pointer); !ok {
+			t := e.tr.typeOf(sel.X)
+			if p, ok := t.Underlying().(*types.Pointer); ok {
+				t = p.Elem()
+			}
+			recv = e.tr.cloneOf(t, recv, x.prec)
+		}
+		e.WriteString(fmt.Sprintf("g.Bind(%s,%s)", recv, SP+name))
+	default:
+		return false
+	}
+	return true
+}
+
+// Returns the function of the method "obj" if its receiver is a named type
+// which is not a struct nor an interface, like "celsius$String", qualified if
+// it is of other package; else an empty string.
+func (tr *transform) staticMethod(obj types.Object) string {
+	sig, ok := obj.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return ""
+	}
+	recv := sig.Recv().Type()
+	if p, ok := recv.(*types.Pointer); ok {
+		recv = p.Elem()
+	}
+
+	named, ok := recv.(*types.Named)
+	if !ok || named.Obj().Pkg() != obj.Pkg() {
+		return ""
+	}
+	switch named.Underlying().(type) {
+	case *types.Struct, *types.Interface:
+		return ""
+	}
+	pkg, ok := tr.qualifier(named.Obj().Pkg())
+	if !ok {
+		return ""
+	}
+	return pkg + named.Obj().Name() + "$" + obj.Name()
+}
+
+// Returns the function of the method declared with the name "name", if its
+// receiver is a named type which is not a struct; else an empty string.
+func (tr *transform) staticMethodOf(name *ast.Ident) string {
+	if tr.info == nil {
+		return ""
+	}
+	obj, ok := tr.info.Defs[name]
+	if !ok || obj == nil {
+		return ""
+	}
+	return tr.staticMethod(obj)
+}
+
+// Writes the call to a method of a named type which is not a struct, like a
+// call to its function; and reports whether it was written.
+func (e *expression) writeStaticCall(call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || e.tr.info == nil {
+		return false
+	}
+	s, ok := e.tr.info.Selections[sel]
+	if !ok || s.Kind() != types.MethodVal {
+		return false
+	}
+	fun := e.tr.staticMethod(s.Obj())
+	if fun == "" {
+		return false
+	}
+
+	args := e.tr.staticRecv(sel, s, false)
+	if len(call.Args) != 0 {
+		args += "," + SP + e.tr.joinArgs(call)
+	}
+	e.WriteString(fun + "(" + args + ")")
+	return true
+}
+
+// Returns the receiver of the method selected by "sel", addressed or
+// dereferenced like the receiver of the method; a value is copied if "isCopy".
+func (tr *transform) staticRecv(sel *ast.SelectorExpr, s *types.Selection, isCopy bool) string {
+	sig := s.Obj().Type().(*types.Signature)
+	_, isPtrRecv := sig.Recv().Type().(*types.Pointer)
+
+	// The promoted methods get the embedded field.
+	if path := tr.embeddedPath(sel); path != "" {
+		x := tr.getExpression(sel.X)
+		return paren(x.String(), x.prec, 11) + path
+	}
+
+	typ := s.Recv()
+	_, isPtrX := typ.(*types.Pointer)
+	if isPtrX {
+		typ = typ.(*types.Pointer).Elem()
+	}
+
+	// The named arrays are objects, which are their address.
+	x := sel.X
+	if !isComposite(typ) {
+		switch {
+		case isPtrRecv && !isPtrX:
+			x = &ast.UnaryExpr{OpPos: x.Pos(), Op: token.AND, X: x}
+		case !isPtrRecv && isPtrX:
+			x = &ast.StarExpr{Star: x.Pos(), X: x}
+		}
+	}
+	recv := tr.getExpression(x)
+
+	if isCopy && !isPtrRecv {
+		return tr.cloneOf(typ, recv.String(), recv.prec)
+	}
+	return recv.String()
+}
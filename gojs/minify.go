@@ -0,0 +1,642 @@
+// Copyright 2012  The "GoScript" Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gojs
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+/*
+## Minification
+
+The minimized version is built from the version to debug, which is split into
+tokens. Then:
+
++ The local variables and parameters of every function are renamed to short
+names. The names declared in the global scope and the exported ones are kept.
+
++ The braces around a block with a single statement are removed, in "if",
+"else", "for" and "while".
+
++ The semicolons before a right brace are removed.
+
++ The white space is removed, but a new line is kept where the automatic
+semicolon insertion of JavaScript could be used.
+
+The tags of the source map are kept in the token which is after them.
+*/
+
+type jsTokenKind uint8
+
+const (
+	jsIdent jsTokenKind = iota // identifiers and keywords
+	jsNumber
+	jsString
+	jsPunct
+)
+
+// Represents a token of JavaScript.
+type jsToken struct {
+	kind jsTokenKind
+	text string
+	nl   bool // is there a new line before?
+
+	scope   *jsScope // scope where the identifier is resolved; or nil
+	deleted bool
+	marks   []int // tags of source map before the token
+}
+
+// Represents the scope of a function.
+type jsScope struct {
+	parent   *jsScope
+	children []*jsScope
+
+	names    []string // declared names, in order
+	declared map[string]bool
+	renamed  map[string]string
+
+	refs []int // index of identifiers used inside
+}
+
+func newJsScope(parent *jsScope) *jsScope {
+	s := &jsScope{
+		parent,
+		make([]*jsScope, 0),
+		make([]string, 0),
+		make(map[string]bool),
+		make(map[string]string),
+		make([]int, 0),
+	}
+
+	if parent != nil {
+		parent.children = append(parent.children, s)
+	}
+	return s
+}
+
+// Declares the name in the scope.
+func (s *jsScope) declare(name string) {
+	if !s.declared[name] {
+		s.declared[name] = true
+		s.names = append(s.names, name)
+	}
+}
+
+// Returns the final name of "name" which is declared in the scope "s", or
+// that is global if "s" is nil.
+func (s *jsScope) finalName(name string) string {
+	if s != nil {
+		if newName, ok := s.renamed[name]; ok {
+			return newName
+		}
+	}
+	return name
+}
+
+// Reserved words in JavaScript.
+var jsKeyword = map[string]bool{
+	"break": true, "case": true, "catch": true, "class": true, "const": true,
+	"continue": true, "debugger": true, "default": true, "delete": true,
+	"do": true, "else": true, "enum": true, "export": true, "extends": true,
+	"false": true, "finally": true, "for": true, "function": true, "if": true,
+	"implements": true, "import": true, "in": true, "instanceof": true,
+	"interface": true, "let": true, "new": true, "null": true, "package": true,
+	"private": true, "protected": true, "public": true, "return": true,
+	"static": true, "super": true, "switch": true, "this": true, "throw": true,
+	"true": true, "try": true, "typeof": true, "var": true, "void": true,
+	"while": true, "with": true, "yield": true,
+}
+
+// Punctuators, the longer ones first.
+var jsPunctuator = []string{
+	">>>=", "===", "!==", ">>>", "<<=", ">>=", "...",
+	"==", "!=", "<=", ">=", "&&", "||", "++", "--", "+=", "-=", "*=", "/=",
+	"%=", "&=", "|=", "^=", "<<", ">>", "=>",
+}
+
+// Minimizes the JavaScript code "src"; the names in "keep" are not renamed.
+// Returns the points where there were tags of source map.
+func minify(src string, keep []string) (string, []srcMapping) {
+	toks := tokenizeJS(src)
+
+	keepName := make(map[string]bool)
+	for _, v := range keep {
+		keepName[v] = true
+	}
+
+	global := resolveJS(toks)
+	for _, s := range global.children {
+		s.rename(toks, keepName)
+	}
+	removeBraces(toks)
+
+	return writeJS(toks)
+}
+
+// Splits the code into tokens; the comments are discarded.
+func tokenizeJS(src string) []*jsToken {
+	toks := make([]*jsToken, 0)
+	marks := make([]int, 0)
+	nl := false
+
+	isIdent := func(c byte) bool {
+		return c == '_' || c == '$' || c >= 'a' && c <= 'z' ||
+			c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+	}
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		start := i
+		kind := jsPunct
+
+		switch {
+		case c == '\n':
+			nl = true
+			i++
+			continue
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+			continue
+
+		case strings.HasPrefix(src[i:], MARK):
+			end := strings.Index(src[i:], ">>")
+			index, _ := strconv.Atoi(src[i+len(MARK) : i+end])
+			marks = append(marks, index)
+			i += end + 2
+			continue
+
+		case strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			continue
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end == -1 {
+				i = len(src)
+			} else {
+				if strings.Contains(src[i:i+2+end], "\n") {
+					nl = true
+				}
+				i += end + 4
+			}
+			continue
+
+		case c == '"' || c == '\'':
+			for i++; i < len(src) && src[i] != c; i++ {
+				if src[i] == '\\' {
+					i++
+				}
+			}
+			i++
+			kind = jsString
+
+		case c >= '0' && c <= '9' || c == '.' && i+1 < len(src) && src[i+1] >= '0' && src[i+1] <= '9':
+			for i++; i < len(src); i++ {
+				if src[i] == '+' || src[i] == '-' { // exponent
+					if prev := src[i-1]; (prev == 'e' || prev == 'E') &&
+						!strings.HasPrefix(src[start:], "0x") &&
+						!strings.HasPrefix(src[start:], "0X") {
+						continue
+					}
+					break
+				}
+				if !isIdent(src[i]) && src[i] != '.' {
+					break
+				}
+			}
+			kind = jsNumber
+
+		case isIdent(c):
+			for i++; i < len(src) && isIdent(src[i]); i++ {
+			}
+			kind = jsIdent
+
+		default:
+			i++
+			for _, p := range jsPunctuator {
+				if strings.HasPrefix(src[start:], p) {
+					i = start + len(p)
+					break
+				}
+			}
+		}
+
+		if i > len(src) {
+			i = len(src)
+		}
+		toks = append(toks, &jsToken{kind: kind, text: src[start:i], nl: nl, marks: marks})
+		marks = make([]int, 0)
+		nl = false
+	}
+
+	return toks
+}
+
+// Builds the scopes of the functions, and resolves every identifier to the
+// scope where it is declared. Returns the global scope.
+func resolveJS(toks []*jsToken) *jsScope {
+	global := newJsScope(nil)
+	scope := global
+
+	// State to restore at closing the body of a function.
+	type state struct {
+		scope    *jsScope
+		inVar    bool
+		varDepth int
+		wantName bool
+	}
+	// Stack of braces; the value is nil if it is not the body of a function.
+	braces := make([]*state, 0)
+
+	var funcScope *jsScope // function whose body is going to be opened
+	inParams := false
+	inVar := false // in the list of names of a "var" declaration
+	varDepth := 0  // depth of brackets inside the "var" declaration
+	wantName := false
+
+	for i, tok := range toks {
+		switch tok.kind {
+		case jsPunct:
+			switch tok.text {
+			case "(", "[":
+				if inVar {
+					varDepth++
+				}
+			case ")", "]":
+				if inParams && tok.text == ")" {
+					inParams = false
+				}
+				if inVar {
+					varDepth--
+				}
+			case "{":
+				if inVar {
+					varDepth++
+				}
+				if funcScope != nil {
+					braces = append(braces, &state{scope, inVar, varDepth, wantName})
+					scope = funcScope
+					funcScope = nil
+					inVar = false
+				} else {
+					braces = append(braces, nil)
+				}
+			case "}":
+				if len(braces) != 0 {
+					if st := braces[len(braces)-1]; st != nil {
+						scope, inVar, varDepth, wantName = st.scope, st.inVar,
+							st.varDepth, st.wantName
+					}
+					braces = braces[:len(braces)-1]
+				}
+				if inVar {
+					varDepth--
+				}
+			case ",":
+				if inVar && varDepth == 0 {
+					wantName = true
+				}
+			case ";":
+				if inVar && varDepth == 0 {
+					inVar = false
+				}
+			}
+			continue
+
+		case jsIdent:
+		default:
+			continue
+		}
+
+		name := tok.text
+		var prev, next *jsToken
+		if i != 0 {
+			prev = toks[i-1]
+		}
+		if i+1 < len(toks) {
+			next = toks[i+1]
+		}
+
+		switch {
+		case name == "function":
+			funcScope = newJsScope(scope)
+
+			// The name of a declaration is in the actual scope.
+			if next != nil && next.kind == jsIdent {
+				scope.declare(next.text)
+			}
+			continue
+		case name == "var" || name == "const" || name == "let":
+			inVar, wantName, varDepth = true, true, 0
+			continue
+		case name == "in" && inVar && varDepth == 0:
+			inVar = false
+			continue
+		case jsKeyword[name]:
+			continue
+		}
+
+		// Properties
+		if prev != nil && prev.text == "." {
+			continue
+		}
+		if prev != nil && (prev.text == "{" || prev.text == ",") &&
+			next != nil && next.text == ":" {
+			continue
+		}
+
+		switch {
+		case funcScope != nil && prev != nil && prev.text == "function":
+			// Name of the function, declared above.
+		case funcScope != nil:
+			if !inParams && prev != nil && prev.text == "(" {
+				inParams = true
+			}
+			if inParams {
+				funcScope.declare(name)
+				tok.scope = funcScope
+				continue
+			}
+		case inVar && wantName && varDepth == 0:
+			scope.declare(name)
+			wantName = false
+		}
+
+		scope.refs = append(scope.refs, i)
+	}
+
+	// Resolve the identifiers.
+	var resolve func(s *jsScope)
+	resolve = func(s *jsScope) {
+		for _, i := range s.refs {
+			for found := s; found != nil; found = found.parent {
+				if found.declared[toks[i].text] {
+					toks[i].scope = found
+					break
+				}
+			}
+		}
+		for _, child := range s.children {
+			resolve(child)
+		}
+	}
+	resolve(global)
+
+	// The tokens declared in the global scope are not renamed.
+	for _, tok := range toks {
+		if tok.scope == global {
+			tok.scope = nil
+		}
+	}
+	return global
+}
+
+// Renames the names declared in the scope, and in its children.
+func (s *jsScope) rename(toks []*jsToken, keep map[string]bool) {
+	used := make(map[string]bool) // names used from outer scopes
+
+	var addUsed func(scope *jsScope)
+	addUsed = func(scope *jsScope) {
+		for _, i := range scope.refs {
+			tok := toks[i]
+			if !s.encloses(tok.scope) {
+				used[tok.scope.finalName(tok.text)] = true
+			}
+		}
+		for _, child := range scope.children {
+			addUsed(child)
+		}
+	}
+	addUsed(s)
+
+	for _, name := range s.names {
+		if keep[name] {
+			used[name] = true
+		}
+	}
+
+	n := 0
+	for _, name := range s.names {
+		if keep[name] {
+			continue
+		}
+		for {
+			newName := shortName(n)
+			n++
+
+			if !used[newName] && !jsKeyword[newName] {
+				s.renamed[name] = newName
+				break
+			}
+		}
+	}
+
+	for _, child := range s.children {
+		child.rename(toks, keep)
+	}
+}
+
+// Reports whether the scope "inner" is "s" or it is inside of it.
+func (s *jsScope) encloses(inner *jsScope) bool {
+	for ; inner != nil; inner = inner.parent {
+		if inner == s {
+			return true
+		}
+	}
+	return false
+}
+
+const shortChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// Returns the short name number "n": "a", ..., "Z", "aa", "ab", ...
+func shortName(n int) string {
+	name := ""
+
+	for {
+		name = string(shortChars[n%len(shortChars)]) + name
+		n = n/len(shortChars) - 1
+
+		if n < 0 {
+			return name
+		}
+	}
+}
+
+// Removes the braces around the blocks with a single statement, in the
+// statements "if", "else", "for" and "while".
+func removeBraces(toks []*jsToken) {
+	parens := make([]int, 0) // index of the left parenthesis opened
+	lParen := make(map[int]int)
+
+	for i, tok := range toks {
+		switch tok.text {
+		case "(":
+			parens = append(parens, i)
+		case ")":
+			if len(parens) != 0 {
+				lParen[i] = parens[len(parens)-1]
+				parens = parens[:len(parens)-1]
+			}
+		}
+	}
+
+	for i, tok := range toks {
+		if tok.text != "{" || i == 0 {
+			continue
+		}
+
+		// Owner of the block
+		prev := toks[i-1]
+		if prev.kind == jsPunct && prev.text == ")" {
+			left, ok := lParen[i-1]
+			if !ok || left == 0 {
+				continue
+			}
+			switch toks[left-1].text {
+			case "if", "for", "while":
+			default:
+				continue
+			}
+		} else if prev.text != "else" {
+			continue
+		}
+
+		// A single statement, finished in semicolon.
+		end := -1
+		depth := 0
+		semicolons := 0
+		for j := i + 1; j < len(toks); j++ {
+			t := toks[j].text
+			if t == "{" {
+				break
+			}
+			if t == "}" {
+				end = j
+				break
+			}
+			switch t {
+			case "(", "[":
+				depth++
+			case ")", "]":
+				depth--
+			case ";":
+				if depth == 0 {
+					semicolons++
+				}
+			}
+		}
+		if end == -1 || end == i+1 || semicolons != 1 || toks[end-1].text != ";" {
+			continue
+		}
+		switch toks[i+1].text {
+		case "var", "const", "let", "function", "if":
+			continue
+		}
+
+		tok.deleted = true
+		toks[i+1].nl = false
+		toks[end].deleted = true
+	}
+}
+
+// Returns the tokens written without white spaces, and the points where
+// there were tags of source map.
+func writeJS(toks []*jsToken) (string, []srcMapping) {
+	var buf bytes.Buffer
+	var last *jsToken // last token written
+
+	maps := make([]srcMapping, 0)
+	marks := make([]int, 0) // tags to add in the next token written
+	line, col := 1, 0
+
+	buf.WriteString(HEADER + "\n")
+
+	for i, tok := range toks {
+		marks = append(marks, tok.marks...)
+		if tok.deleted {
+			continue
+		}
+
+		// Semicolon before a right brace.
+		if tok.text == ";" {
+			next := (*jsToken)(nil)
+			for j := i + 1; j < len(toks); j++ {
+				if !toks[j].deleted {
+					next = toks[j]
+					break
+				}
+			}
+			if next != nil && next.text == "}" {
+				continue
+			}
+		}
+
+		text := tok.scope.finalName(tok.text)
+
+		if last != nil {
+			switch {
+			case tok.nl && canEndStatement(last) && canStartStatement(tok):
+				buf.WriteString("\n")
+				line++
+				col = 0
+			case last.kind != jsPunct && tok.kind != jsPunct && tok.kind != jsString &&
+				last.kind != jsString:
+				buf.WriteString(" ")
+				col++
+			case last.kind == jsNumber && tok.text == ".",
+				strings.HasSuffix(last.text, "+") && strings.HasPrefix(text, "+"),
+				strings.HasSuffix(last.text, "-") && strings.HasPrefix(text, "-"):
+				buf.WriteString(" ")
+				col++
+			}
+		}
+
+		for _, m := range marks {
+			maps = append(maps, srcMapping{line, col, m})
+		}
+		marks = marks[:0]
+
+		buf.WriteString(text)
+		col += utf16Len(text)
+		last = tok
+	}
+
+	buf.WriteString("\n")
+	return buf.String(), maps
+}
+
+// Reports whether a statement could finish in the token.
+func canEndStatement(tok *jsToken) bool {
+	switch tok.text {
+	case "}", ")", "]", "++", "--":
+		return true
+	}
+	return tok.kind != jsPunct
+}
+
+// Reports whether a new statement could start with the token, so that the
+// automatic semicolon insertion could be used before it.
+func canStartStatement(tok *jsToken) bool {
+	switch tok.text {
+	case "else", "in", "instanceof", "catch", "finally":
+		return false
+	case "++", "--", "{":
+		return true
+	}
+	return tok.kind != jsPunct
+}
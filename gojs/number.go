@@ -0,0 +1,485 @@
+// Copyright 2012  The "GoScript" Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gojs
+
+import (
+	"fmt"
+	"go/ast"
+	exact "go/constant"
+	"go/token"
+	"go/types"
+	"math"
+	"strconv"
+)
+
+/*
+## Numbers
+
+JavaScript has only a type of number, a float64. Then, the integers of 8, 16
+and 32 bits are truncated to their size after each operation which could
+overflow:
+
+	int8:   x << 24 >> 24
+	int16:  x << 16 >> 16
+	int32:  x | 0
+	uint8:  x & 255
+	uint16: x & 65535
+	uint32: x >>> 0
+
+The multiplication of integers of 32 bits is done by "Math.imul", since the
+product could not be represented exactly; and the values of type float32 are
+rounded by "Math.fround".
+
+The types int and uint are represented exactly until 2^53, without truncation.
+Since the bitwise operators of JavaScript work on 32 bits, theirs are done by
+the gojs's package, and the shifts are multiplications and divisions by a power
+of 2:
+
+	x | y   =>  g.Or(x, y)
+	x << 3  =>  x * 8
+	x >> 3  =>  Math.floor(x / 8)
+
+The integer division, and the remainder, panic if the divisor is zero; so they
+are done by the gojs's package. Like the shifts whose count is not constant,
+since JavaScript only uses the 5 lower bits of the count.
+
+The integers of 64 bits are objects of the gojs's package, "g.Int64" and
+"g.Uint64", which store the high and low 32 bits. Their operators are
+transformed in calls to methods, which return a new value:
+
+	x + y   =>  x.add(y)
+	x << n  =>  x.shl(n)
+	x == y  =>  x.eq(y)
+	x < y   =>  x.cmp(y) < 0
+	-x      =>  x.neg()
+
+and the constants are built from their bits: "new g.Int64(hi, lo)".
+
+The complex numbers are objects of type "g.Complex", with their real and
+imaginary parts; their arithmetic operators, and the equality, are methods too.
+The values of type complex64 are rounded by the method "fround".
+*/
+
+// Precedence of the binary operators in JavaScript.
+var jsPrecedence = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"|":  3,
+	"^":  4,
+	"&":  5,
+	"==": 6, "!=": 6, "===": 6, "!==": 6,
+	"<": 7, "<=": 7, ">": 7, ">=": 7,
+	"<<": 8, ">>": 8, ">>>": 8,
+	"+": 9, "-": 9,
+	"*": 10, "/": 10, "%": 10,
+}
+
+// Returns the expression "js", whose operator at the top has precedence
+// "prec", to be used like an operand of an operator of precedence "min".
+func paren(js string, prec, min int) string {
+	if prec != 0 && prec <= min {
+		return "(" + js + ")"
+	}
+	return js
+}
+
+// Returns the basic type of a number; or nil if "typ" is not a number.
+func numberOf(typ types.Type) *types.Basic {
+	if typ == nil {
+		return nil
+	}
+	if b, ok := typ.Underlying().(*types.Basic); ok && b.Info()&types.IsNumeric != 0 {
+		return b
+	}
+	return nil
+}
+
+// Reports whether the values of an integer of kind "k" are truncated to 32
+// bits or less.
+func isSized(k types.BasicKind) bool {
+	switch k {
+	case types.Int8, types.Int16, types.Int32, types.Uint8, types.Uint16, types.Uint32:
+		return true
+	}
+	return false
+}
+
+// Returns the number "js", whose operator at the top has precedence "prec",
+// truncated to the size of the type "b"; and the new precedence.
+func truncate(b *types.Basic, js string, prec int) (string, int) {
+	var op, value string
+
+	switch b.Kind() {
+	case types.Int8:
+		op, value = "<<", "24"+SP+">>"+SP+"24"
+	case types.Int16:
+		op, value = "<<", "16"+SP+">>"+SP+"16"
+	case types.Int32:
+		op, value = "|", "0"
+	case types.Uint8:
+		op, value = "&", "255"
+	case types.Uint16:
+		op, value = "&", "65535"
+	case types.Uint32:
+		op, value = ">>>", "0"
+	case types.Float32:
+		return "Math.fround(" + js + ")", 0
+	case types.Complex64:
+		return paren(js, prec, 11) + ".fround()", 0
+	default:
+		return js, prec
+	}
+
+	p := jsPrecedence[op]
+	return paren(js, prec, p) + SP + op + SP + value, p
+}
+
+// Returns the binary operation "x op y", whose result is of type "typ";
+// and the precedence of the operator at the top.
+// The argument "yExpr" is the right operand in Go, to know if it is constant.
+func (tr *transform) binaryOp(op token.Token, typ types.Type, x, y *expression, yExpr ast.Expr) (string, int) {
+	opStr := op.String()
+	b := numberOf(typ)
+
+	if isObject(b) {
+		return tr.methodOp(op, b, x, y, yExpr)
+	}
+
+	// The operands of the operator in JavaScript.
+	join := func(opStr string) (string, int) {
+		p := jsPrecedence[opStr]
+		return paren(x.String(), x.prec, p-1) + SP + opStr + SP + paren(y.String(), y.prec, p), p
+	}
+	call := func(fn string) (string, int) {
+		return fn + "(" + x.String() + "," + SP + y.String() + ")", 0
+	}
+
+	// The operators of JavaScript are of 32 bits.
+	if b != nil && isWide(b) {
+		switch op {
+		case token.AND:
+			return call("g.And")
+		case token.OR:
+			return call("g.Or")
+		case token.XOR:
+			return call("g.Xor")
+		case token.AND_NOT:
+			return call("g.AndNot")
+		}
+	}
+
+	if op == token.AND_NOT {
+		p := jsPrecedence["&"]
+		js := paren(x.String(), x.prec, p-1) + SP + "&" + SP + "~" + paren(y.String(), y.prec, 11)
+
+		if b != nil && b.Kind() == types.Uint32 {
+			return truncate(b, js, p)
+		}
+		return js, p
+	}
+
+	if b == nil || b.Info()&types.IsInteger == 0 && b.Kind() != types.Float32 {
+		return join(opStr)
+	}
+
+	if b.Kind() == types.Float32 {
+		js, p := join(opStr)
+		return truncate(b, js, p)
+	}
+
+	// == Integers
+
+	// The value of the right operand, if it is constant.
+	var yConst uint64
+	isConst := false
+
+	if tv, ok := tr.info.Types[yExpr]; ok && tv.Value != nil {
+		yConst, _ = exact.Uint64Val(exact.ToInt(tv.Value))
+		isConst = true
+	}
+
+	var js string
+	var p int
+
+	switch op {
+	case token.MUL:
+		switch b.Kind() {
+		case types.Int32:
+			return call("Math.imul")
+		case types.Uint32:
+			js, p = call("Math.imul")
+		default:
+			js, p = join(opStr)
+		}
+
+	case token.QUO:
+		switch {
+		case !isConst || yConst == 0:
+			js, p = call("g.Quo")
+		case isSized(b.Kind()):
+			js, p = join(opStr)
+		default:
+			return "Math.trunc(" + x.String() + SP + "/" + SP + paren(y.String(), y.prec, 10) + ")", 0
+		}
+	case token.REM:
+		if !isConst || yConst == 0 {
+			return call("g.Rem")
+		}
+		return join(opStr)
+
+	case token.SHL, token.SHR:
+		if isWide(b) {
+			if !isConst || yConst >= 64 {
+				if op == token.SHL {
+					return call("g.Shl")
+				}
+				return call("g.Shr")
+			}
+			pow := strconv.FormatFloat(math.Ldexp(1, int(yConst)), 'f', -1, 64)
+
+			if op == token.SHL {
+				p := jsPrecedence["*"]
+				return paren(x.String(), x.prec, p-1) + SP + "*" + SP + pow, p
+			}
+			return "Math.floor(" + paren(x.String(), x.prec, 9) + SP + "/" + SP + pow + ")", 0
+		}
+		if b.Info()&types.IsUnsigned != 0 && op == token.SHR {
+			opStr = ">>>"
+		}
+		isConst = isConst && yConst < 32
+
+		if op == token.SHR {
+			if isConst {
+				return join(opStr)
+			}
+			return call("g.Shr")
+		}
+
+		if !isConst {
+			js, p = call("g.Shl")
+		} else if js, p = join(opStr); b.Kind() == types.Int32 {
+			return js, p
+		}
+
+	case token.AND, token.OR, token.XOR:
+		js, p = join(opStr)
+		if b.Kind() != types.Uint32 {
+			return js, p
+		}
+
+	default: // + -
+		js, p = join(opStr)
+	}
+
+	return truncate(b, js, p)
+}
+
+// Reports whether the operation "op" on values of type "typ" is transformed
+// by binaryOp in something other than the same operator of JavaScript.
+func isLowered(op token.Token, typ types.Type) bool {
+	b := numberOf(typ)
+
+	switch {
+	case b == nil:
+		return false
+	case isSized(b.Kind()), b.Kind() == types.Float32, isObject(b):
+		return true
+	case isWide(b):
+		switch op {
+		case token.QUO, token.REM, token.SHL, token.SHR,
+			token.AND, token.OR, token.XOR, token.AND_NOT:
+			return true
+		}
+	}
+	return false
+}
+
+// Reports whether "b" is an integer which is not truncated, like int and
+// uint; so its bitwise operators can not be the ones of JavaScript.
+func isWide(b *types.Basic) bool {
+	return b.Info()&types.IsInteger != 0 && !isSized(b.Kind()) && !isObject(b)
+}
+
+// Operators of the assignments which can be lowered, with their binary
+// operator.
+var assignOp = map[token.Token]token.Token{
+	token.ADD_ASSIGN: token.ADD,
+	token.SUB_ASSIGN: token.SUB,
+	token.MUL_ASSIGN: token.MUL,
+	token.QUO_ASSIGN: token.QUO,
+	token.REM_ASSIGN: token.REM,
+	token.AND_ASSIGN: token.AND,
+	token.OR_ASSIGN:  token.OR,
+	token.XOR_ASSIGN: token.XOR,
+	token.SHL_ASSIGN: token.SHL,
+	token.SHR_ASSIGN: token.SHR,
+
+	token.AND_NOT_ASSIGN: token.AND_NOT,
+}
+
+// Range of values of the integers truncated.
+var intRange = map[types.BasicKind][2]float64{
+	types.Int8:   {-1 << 7, 1<<7 - 1},
+	types.Int16:  {-1 << 15, 1<<15 - 1},
+	types.Int32:  {-1 << 31, 1<<31 - 1},
+	types.Uint8:  {0, 1<<8 - 1},
+	types.Uint16: {0, 1<<16 - 1},
+	types.Uint32: {0, 1<<32 - 1},
+}
+
+// Returns the conversion of the number "js", whose operator at the top has
+// precedence "prec", from the type "from" to "to"; and the new precedence.
+// Without type information, the value is not modified.
+func convert(to, from *types.Basic, js string, prec int) (string, int) {
+	if to == nil || from == nil {
+		return js, prec
+	}
+
+	if isComplex(to) {
+		if to.Kind() == types.Complex64 && from.Kind() == types.Complex128 {
+			return truncate(to, js, prec)
+		}
+		return js, prec
+	}
+	if is64(to) {
+		switch {
+		case from.Kind() == to.Kind():
+			return js, prec
+		case is64(from):
+			return paren(js, prec, 11) + ".to" + name64[to.Kind()] + "()", 0
+		}
+		return "g.To" + name64[to.Kind()] + "(" + js + ")", 0
+	}
+	// The low 32 bits are enough for the integers truncated.
+	if is64(from) {
+		if isSized(to.Kind()) {
+			js, from = paren(js, prec, 11)+".lo", types.Typ[types.Uint32]
+		} else {
+			js, from = paren(js, prec, 11)+".toNumber()", types.Typ[types.Int]
+		}
+		prec = 0
+	}
+
+	switch {
+	case to.Kind() == types.Float32:
+		if from.Kind() == types.Float32 {
+			return js, prec
+		}
+		return truncate(to, js, prec)
+	case to.Info()&types.IsFloat != 0:
+		return js, prec
+	}
+
+	// == Integers
+	if from.Info()&types.IsFloat != 0 {
+		if isSized(to.Kind()) {
+			return truncate(to, js, prec) // it also truncates the decimals
+		}
+		return "Math.trunc(" + js + ")", 0
+	}
+
+	if !isSized(to.Kind()) {
+		return js, prec
+	}
+	if r, ok := intRange[from.Kind()]; ok && r[0] >= intRange[to.Kind()][0] &&
+		r[1] <= intRange[to.Kind()][1] {
+		return js, prec
+	}
+	return truncate(to, js, prec)
+}
+
+// == Numbers like objects
+
+// Name of the types of the gojs's package for the integers of 64 bits.
+var name64 = map[types.BasicKind]string{
+	types.Int64:  "Int64",
+	types.Uint64: "Uint64",
+}
+
+// Methods of the numbers like objects for the binary operators.
+var opMethod = map[token.Token]string{
+	token.ADD:     "add",
+	token.SUB:     "sub",
+	token.MUL:     "mul",
+	token.QUO:     "div",
+	token.REM:     "rem",
+	token.AND:     "and",
+	token.OR:      "or",
+	token.XOR:     "xor",
+	token.AND_NOT: "andNot",
+	token.SHL:     "shl",
+	token.SHR:     "shr",
+}
+
+// Reports whether "b" is an integer of 64 bits.
+func is64(b *types.Basic) bool {
+	return b != nil && (b.Kind() == types.Int64 || b.Kind() == types.Uint64)
+}
+
+// Reports whether "b" is a complex number.
+func isComplex(b *types.Basic) bool {
+	return b != nil && b.Info()&types.IsComplex != 0
+}
+
+// Reports whether the numbers of type "b" are objects of the gojs's package,
+// whose operators are methods.
+func isObject(b *types.Basic) bool {
+	return is64(b) || isComplex(b)
+}
+
+// Returns the integer of 64 bits of type "b" whose bits are "u".
+func literal64(b *types.Basic, u uint64) string {
+	return fmt.Sprintf("new g.%s(%d,%s%d)", name64[b.Kind()], u>>32, SP, u&(1<<32-1))
+}
+
+// Returns the complex number of type "b" with parts "re" and "im".
+func literalComplex(b *types.Basic, re, im float64) string {
+	if b.Kind() == types.Complex64 {
+		re, im = float64(float32(re)), float64(float32(im))
+	}
+	return "new g.Complex(" + strconv.FormatFloat(re, 'g', -1, 64) + "," + SP +
+		strconv.FormatFloat(im, 'g', -1, 64) + ")"
+}
+
+// Returns the binary operation "x op y" on numbers of type "b", which are
+// objects, like a call to a method; and its precedence.
+func (tr *transform) methodOp(op token.Token, b *types.Basic, x, y *expression, yExpr ast.Expr) (string, int) {
+	arg := y.String()
+
+	// The shift count is a number.
+	if op == token.SHL || op == token.SHR {
+		if is64(numberOf(tr.typeOf(yExpr))) {
+			arg = paren(arg, y.prec, 11) + ".toNumber()"
+		}
+	}
+	return truncate(b, paren(x.String(), x.prec, 11)+"."+opMethod[op]+"("+arg+")", 0)
+}
+
+// Returns the comparison "x op y" of numbers which are objects, and its
+// precedence; or false if "op" does not compare.
+func compareMethod(op token.Token, x, y *expression) (js string, prec int, ok bool) {
+	xStr := paren(x.String(), x.prec, 11)
+
+	switch op {
+	case token.EQL:
+		return xStr + ".eq(" + y.String() + ")", 0, true
+	case token.NEQ:
+		return "!" + xStr + ".eq(" + y.String() + ")", 0, true
+	case token.LSS, token.GTR, token.LEQ, token.GEQ:
+		return xStr + ".cmp(" + y.String() + ")" + SP + op.String() + SP + "0",
+			jsPrecedence[op.String()], true
+	}
+	return "", 0, false
+}
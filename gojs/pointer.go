@@ -16,162 +16,114 @@ package gojs
 
 import (
 	"fmt"
-	"regexp"
-	"strings"
+	"go/ast"
+	"go/token"
+	"go/types"
 )
 
 /*
 ## Pointers
 
-To identify variables that could be addressed ahead, it is used the map:
+A pointer to a struct or an array is the object which it points to. The rest
+of pointers are boxes "{p: value}", whose field "p" is the value pointed; and
+the nil pointer is "undefined":
 
-	{number of function: {number of block: {variable name: is pointer?} }}
+	p := new(int)  =>  var p = {p:0};
+	*p = 2         =>  p.p = 2;
 
-In the generated code, it is added a tag before and after of each new variable
-but pointer. The tag uses the schema `<<side:funcId:blockId:varName>>`
+Before translating, the package is inspected to find the variables whose
+address is taken, by the operator "&" or by calling a method with a pointer
+receiver, including the ones of the function literals. Those variables are
+declared like boxes, so their address is the box:
 
-	*side:* *L* or *R* if the tag is on the left or on the right of the variable.
-		*i* indicates that its value is zero for the value type.
-	*funcId:* identifier of function. '0' is for global declarations
-	*blockId:* number of block inner of that function. Start with '1'
-	*varName:* variable's name
+	x := 3         =>  var x = {p:3};
+	y := &x        =>  var y = x;
+	x++            =>  x.p++;
 
-It is also added the tag `<<P:funcId:blockId:varName>>` after of each variable
-name, and `<<&>>` after of it when the assignment is an address.
+The address of a field or an element which is not a struct nor an array is
+not supported.
 */
 
-// To remove tags related to pointers
-var reTagPointer = regexp.MustCompile(`<<z?[LRP]:\d+:\d+:[^>]+>>`)
+// Returns the variables whose address is taken, which are not structs nor
+// arrays: by the operator "&", or by calling a method with a pointer receiver.
+func addressedVars(files []*ast.File, info *types.Info) map[types.Object]bool {
+	vars := make(map[types.Object]bool)
 
-// Returns a tag to identify pointers.
-// The argument field indicates if the variable is zero.
-func tagPointer(zero bool, typ rune, funcId, blockId int, name string) string {
-	/*if typ != 'L' && typ != 'R' && typ != 'P' {
-		panic("invalid identifier for pointer: " + string(typ))
-	}*/
-
-	zeroStr := ""
-	if zero {
-		zeroStr = "z"
-	}
-
-	return fmt.Sprintf("<<%s:%d:%d:%s>>", zeroStr + string(typ), funcId, blockId, name)
-}
-
-// Search the point where the variable was declared for tag it as pointer.
-func (tr *transform) addPointer(name string) {
-	// In the actual function
-	if tr.funcId != 0 {
-		for block := tr.blockId; block >= 1; block-- {
-			if _, ok := tr.vars[tr.funcId][block][name]; ok {
-				tr.vars[tr.funcId][block][name] = true
-				return
-			}
-		}
-	}
-
-	// Finally, search in the global variables (funcId = 0).
-	for block := tr.blockId; block >= 0; block-- { // block until 0
-		if _, ok := tr.vars[0][block][name]; ok {
-			tr.vars[0][block][name] = true
+	add := func(x ast.Expr) {
+		ident, ok := ast.Unparen(x).(*ast.Ident)
+		if !ok {
 			return
 		}
+		if v, ok := info.Uses[ident].(*types.Var); ok && !v.IsField() && !isComposite(v.Type()) {
+			vars[v] = true
+		}
 	}
-	//fmt.Printf("Function %d, block %d, name %s\n", tr.funcId, tr.blockId, name)
-	panic("addPointer: unreachable")
-}
 
-// Replaces tags related to variables addressed.
-func (tr *transform) replacePointers(str *string) {
-	// Replaces tags in variables that access to pointers.
-	replaceLocal := func(funcId, startBlock, endBlock int, varName string) {
-		for block := startBlock; block <= endBlock; block++ {
-			// Check if there is a variable named like the pointer in another block.
-			if block != startBlock {
-				if _, ok := tr.vars[funcId][block][varName]; ok {
+	for _, f := range files {
+		ast.Inspect(f, func(node ast.Node) bool {
+			switch t := node.(type) {
+			case *ast.UnaryExpr:
+				if t.Op == token.AND {
+					add(t.X)
+				}
+			case *ast.SelectorExpr:
+				s, ok := info.Selections[t]
+				if !ok || s.Kind() != types.MethodVal || len(s.Index()) != 1 {
 					break
 				}
-			}
-
-			pointer := tagPointer(false, 'P', funcId, block, varName)
-
-			// Comparing pointers with value nil
-			reNil := regexp.MustCompile(pointer + NIL)
-			*str = reNil.ReplaceAllString(*str, ".p")
-
-			if tr.addr[funcId][block][varName] {
-				*str = strings.Replace(*str, pointer+ADDR, "", -1)
-			} else {
-				*str = strings.Replace(*str, pointer, ".p", -1)
-			}
-		}
-	}
-
-	// In each function
-	for funcId, blocks := range tr.vars {
-		for blockId := 0; blockId <= len(blocks); blockId++ {
-			for name, isPointer := range tr.vars[funcId][blockId] {
-				if isPointer {
-					replaceLocal(funcId, blockId, len(blocks), name)
-
-					// Replace brackets around variables addressed.
-					lBrack := tagPointer(false, 'L', funcId, blockId, name)
-					rBrack := tagPointer(false, 'R', funcId, blockId, name)
+				sig := s.Obj().Type().(*types.Signature)
+				_, isPtrRecv := sig.Recv().Type().(*types.Pointer)
+				_, isPtrX := s.Recv().(*types.Pointer)
 
-					*str = strings.Replace(*str, lBrack, "{p:", 1)
-					*str = strings.Replace(*str, rBrack, "}", 1)
-
-					// The declaration of pointers without initial value
-					// have type "nil" in Go
-					iLBrack := tagPointer(true, 'L', funcId, blockId, name)
-					iRBrack := tagPointer(true, 'R', funcId, blockId, name)
-					re := regexp.MustCompile(iLBrack + `[^<]+` + iRBrack)
-
-					*str = re.ReplaceAllString(*str, "{p:undefined}")
+				if isPtrRecv && !isPtrX {
+					add(t.X)
 				}
 			}
-		}
+			return true
+		})
 	}
+	return vars
+}
 
-	// === Global pointers
-	globalScope := 0
-
-	replaceGlobal := func(globVarName string) {
-		for funcId, blocks := range tr.vars {
-			if funcId == globalScope {
-				continue
-			}
-
-			for blockId := 1; blockId <= len(blocks); blockId++ {
-				if _, ok := tr.vars[funcId][blockId][globVarName]; ok {
-					continue
-				}
-
-				pointer := tagPointer(false, 'P', funcId, blockId, globVarName)
+// Reports whether the identifier uses a variable whose address is taken.
+func (tr *transform) isAddressed(ident *ast.Ident) bool {
+	if tr.info == nil {
+		return false
+	}
+	obj, ok := tr.info.Uses[ident]
+	return ok && tr.addressed[obj]
+}
 
-				reNil := regexp.MustCompile(pointer + NIL)
-				*str = reNil.ReplaceAllString(*str, ".p")
+// Returns the value "js" of the variable defined by "expr", which is stored in
+// a box if its address is taken.
+func (tr *transform) defValue(expr ast.Expr, js string) string {
+	if tr.info == nil {
+		return js
+	}
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return js
+	}
+	if obj, ok := tr.info.Defs[ident]; ok && tr.addressed[obj] {
+		return "{p:" + js + "}"
+	}
+	return js
+}
 
-				if tr.addr[funcId][blockId][globVarName] {
-					*str = strings.Replace(*str, pointer+ADDR, "", -1)
-				} else {
-					*str = strings.Replace(*str, pointer, ".p", -1)
-				}
-			}
-		}
+// Returns the assignments which store in a box the parameters whose address is
+// taken, at the start of the function.
+func (tr *transform) boxParams(list *ast.FieldList) string {
+	if list == nil {
+		return ""
 	}
+	boxes := ""
 
-	for globBlockId := 0; globBlockId <= len(tr.vars[globalScope]); globBlockId++ {
-		for globName, isPointer := range tr.vars[globalScope][globBlockId] {
-			if isPointer {
-				replaceGlobal(globName)
+	for _, field := range list.List {
+		for _, v := range field.Names {
+			if js := tr.defValue(v, v.Name); js != v.Name {
+				boxes += fmt.Sprintf("%s=%s;", v.Name+SP, SP+js)
 			}
 		}
 	}
-
-	// * * *
-
-	// Remove the tags.
-	*str = reTagPointer.ReplaceAllString(*str, "")
-	*str = strings.Replace(*str, NIL, "", -1)
+	return boxes
 }
@@ -0,0 +1,221 @@
+// Copyright 2012  The "GoScript" Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gojs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+## Source maps
+
+While the code is generated, it is added the tag `<<M:index>>` in the points
+which are mapped to the Go source; the index is the one of the position in the
+list *transform.marks*. At writing the output, the tags are removed and the
+position where they were is stored.
+
+The map follows the format "Source Map Revision 3":
+https://docs.google.com/document/d/1U1RGAehQwRypUTovF1KRlpiOFze0b-_2gc6fAH0KY0k
+*/
+
+const MARK = "<<M:" // tag to map a position, closed by ">>"
+
+// Represents a point of the Go source code.
+type srcMark struct {
+	pos  token.Pos
+	name string // name of the identifier; or empty
+}
+
+// Represents a mapping from the generated code to the Go source.
+type srcMapping struct {
+	line, col int // 0-based, the column in UTF-16 units
+	mark      int // index in *transform.marks
+}
+
+// Returns the tag to map the actual point of the output to "pos".
+func (tr *transform) mark(pos token.Pos, name string) string {
+	if !pos.IsValid() {
+		return ""
+	}
+
+	tr.marks = append(tr.marks, srcMark{pos, name})
+	return fmt.Sprintf("%s%d>>", MARK, len(tr.marks)-1)
+}
+
+// Adds the tag to map the actual point of the output to "pos".
+func (tr *transform) addMark(pos token.Pos, name string) {
+	tr.WriteString(tr.mark(pos, name))
+}
+
+// Returns the length of "s" in UTF-16 units, as JavaScript does.
+func utf16Len(s string) int {
+	n := 0
+
+	for _, r := range s {
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// Removes the tags of mapping in "str", returning the points where they were.
+func extractMarks(str string) (string, []srcMapping) {
+	var buf bytes.Buffer
+	maps := make([]srcMapping, 0)
+	line, col := 0, 0
+
+	for {
+		i := strings.Index(str, MARK)
+		if i == -1 {
+			break
+		}
+
+		// Position of the tag
+		text := str[:i]
+		if nl := strings.LastIndex(text, "\n"); nl != -1 {
+			line += strings.Count(text, "\n")
+			col = utf16Len(text[nl+1:])
+		} else {
+			col += utf16Len(text)
+		}
+		buf.WriteString(text)
+
+		str = str[i+len(MARK):]
+		end := strings.Index(str, ">>")
+		index, _ := strconv.Atoi(str[:end])
+		str = str[end+2:]
+
+		maps = append(maps, srcMapping{line, col, index})
+	}
+
+	buf.WriteString(str)
+	return buf.String(), maps
+}
+
+// Returns the source map, in JSON, of the file "jsFile" from its mappings.
+// The paths of the Go files are relative to the directory of "jsFile".
+func (tr *transform) sourceMap(jsFile string, maps []srcMapping) []byte {
+	sources := make([]string, 0)
+	sourceIdx := make(map[string]int)
+	names := make([]string, 0)
+	nameIdx := make(map[string]int)
+
+	sort.SliceStable(maps, func(i, j int) bool {
+		if maps[i].line != maps[j].line {
+			return maps[i].line < maps[j].line
+		}
+		return maps[i].col < maps[j].col
+	})
+
+	var buf bytes.Buffer
+	line := 0
+	prev := [5]int{} // last values of every field
+	isFirst := true
+
+	for _, m := range maps {
+		mark := tr.marks[m.mark]
+		pos := tr.fset.Position(mark.pos)
+
+		// Index of the source file
+		src, ok := sourceIdx[pos.Filename]
+		if !ok {
+			name := pos.Filename
+			if rel, err := filepath.Rel(filepath.Dir(jsFile), name); err == nil {
+				name = filepath.ToSlash(rel)
+			}
+
+			src = len(sources)
+			sourceIdx[pos.Filename] = src
+			sources = append(sources, name)
+		}
+
+		// The column is reset at every line.
+		for ; line < m.line; line++ {
+			buf.WriteByte(';')
+			prev[0] = 0
+			isFirst = true
+		}
+		if !isFirst {
+			buf.WriteByte(',')
+		}
+		isFirst = false
+
+		fields := []int{m.col, src, pos.Line - 1, pos.Column - 1}
+
+		if mark.name != "" {
+			idx, ok := nameIdx[mark.name]
+			if !ok {
+				idx = len(names)
+				nameIdx[mark.name] = idx
+				names = append(names, mark.name)
+			}
+			fields = append(fields, idx)
+		}
+
+		for i, v := range fields {
+			writeVLQ(&buf, v-prev[i])
+			prev[i] = v
+		}
+	}
+
+	smap, _ := json.Marshal(struct {
+		Version  int      `json:"version"`
+		File     string   `json:"file"`
+		Sources  []string `json:"sources"`
+		Names    []string `json:"names"`
+		Mappings string   `json:"mappings"`
+	}{3, filepath.Base(jsFile), sources, names, buf.String()})
+
+	return smap
+}
+
+const base64Chars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+// Writes the value encoded in Base64 VLQ.
+func writeVLQ(buf *bytes.Buffer, value int) {
+	v := value << 1
+	if value < 0 {
+		v = (-value << 1) | 1
+	}
+
+	for {
+		digit := v & 31
+		v >>= 5
+
+		if v != 0 {
+			digit |= 32 // continuation
+		}
+		buf.WriteByte(base64Chars[digit])
+
+		if v == 0 {
+			return
+		}
+	}
+}
+
+// Returns the comment to link the code to its source map.
+func sourceMapURL(jsFile string) string {
+	return "//# sourceMappingURL=" + filepath.Base(jsFile) + ".map\n"
+}
@@ -17,7 +17,9 @@ package gojs
 import (
 	"fmt"
 	"go/ast"
+	exact "go/constant"
 	"go/token"
+	"go/types"
 	"strings"
 )
 
@@ -28,20 +30,23 @@ type dataStmt struct {
 	blockId   int // number of block
 	tabLevel  int // tabulation level
 
-	lenCase int // number of "case" statements
-	iCase   int // index in "case" statements
+	lenCase    int    // number of "case" statements
+	iCase      int    // index in "case" statements
+	typeSwitch string // variable checked by the clauses of a type switch
 
 	isConst        bool
 	isVar          bool
-	initIsPointer  bool // the value initialized is a pointer?
 	wasFallthrough bool // the last statement was "fallthrough"?
 	wasReturn      bool // the last statement was "return"?
 	skipLbrace     bool // left brace
 	skipSemicolon  bool
 
-	lastVarName string // for composite types
-	results     string // variables names that return must use
-	recvVar     string // receiver variable (in methods)
+	lastVarName string         // for composite types
+	results     string         // variables names that return must use
+	resultTypes *types.Tuple   // types of the results of the actual function
+	funcType    *ast.FuncType  // signature of the actual function
+	funcBody    *ast.BlockStmt // body of the actual function
+	recvVar     string         // receiver variable (in methods)
 }
 
 // Transforms the Go statement.
@@ -69,8 +74,7 @@ func (tr *transform) getStatement(stmt ast.Stmt) {
 	//  Rbrace token.Pos // position of "}"
 	case *ast.BlockStmt:
 		tr.blockId++
-		tr.vars[tr.funcId][tr.blockId] = make(map[string]bool)
-		tr.addr[tr.funcId][tr.blockId] = make(map[string]bool)
+		tr.vars[tr.funcId][tr.blockId] = make(map[string]struct{})
 		tr.maps[tr.funcId][tr.blockId] = make(map[string]struct{})
 		tr.slices[tr.funcId][tr.blockId] = make(map[string]struct{})
 		tr.zeroType[tr.funcId][tr.blockId] = make(map[string]string)
@@ -99,6 +103,7 @@ func (tr *transform) getStatement(stmt ast.Stmt) {
 				tr.WriteString(SP)
 			}
 
+			tr.addMark(v.Pos(), "")
 			tr.getStatement(v)
 
 			if !skipTab {
@@ -141,7 +146,7 @@ func (tr *transform) getStatement(stmt ast.Stmt) {
 		case token.FALLTHROUGH:
 			tr.wasFallthrough = true
 		case token.GOTO: // not used since "label" is not transformed
-			tr.addError("%s: goto directive", tr.fset.Position(typ.TokPos))
+			tr.addError(typ.TokPos, "unsupported-goto", "goto directive")
 		}
 
 	// godoc go/ast CaseClause
@@ -157,7 +162,9 @@ func (tr *transform) getStatement(stmt ast.Stmt) {
 		tr.iCase++
 		tr.addLine(typ.Case)
 
-		if typ.List != nil {
+		if tr.typeSwitch != "" {
+			tr.writeTypeCase(typ)
+		} else if typ.List != nil {
 			for i, expr := range typ.List {
 				if i != 0 {
 					tr.WriteString(SP)
@@ -166,10 +173,11 @@ func (tr *transform) getStatement(stmt ast.Stmt) {
 			}
 		} else {
 			tr.WriteString("default:")
-
+		}
+		if typ.List == nil {
 			if tr.iCase != tr.lenCase {
-				tr.addWarning("%s: 'default' clause above 'case' clause in switch statement",
-					tr.fset.Position(typ.Pos()))
+				tr.addWarning(typ.Pos(), "default-not-last",
+					"'default' clause above 'case' clause in switch statement")
 			}
 		}
 
@@ -180,7 +188,11 @@ func (tr *transform) getStatement(stmt ast.Stmt) {
 				} else {
 					tr.WriteString(SP)
 				}
+				tr.addMark(v.Pos(), "")
 				tr.getStatement(v)
+
+				// Only a return at the end of the clause ends it.
+				_, tr.wasReturn = v.(*ast.ReturnStmt)
 			}
 		}
 
@@ -204,7 +216,8 @@ func (tr *transform) getStatement(stmt ast.Stmt) {
 				panic("unreachable")
 			}
 		default:
-			panic(fmt.Sprintf("unimplemented: %T", decl))
+			tr.addError(decl.Pos(), "unsupported-declaration",
+				"declaration of kind %s", nodeKind(decl))
 		}
 
 	// godoc go/ast ExprStmt
@@ -251,7 +264,7 @@ func (tr *transform) getStatement(stmt ast.Stmt) {
 	//  Go   token.Pos // position of "go" keyword
 	//  Call *CallExpr
 	case *ast.GoStmt:
-		tr.addError("%s: goroutine", tr.fset.Position(typ.Go))
+		tr.addError(typ.Go, "unsupported-goroutine", "goroutine")
 
 	// http://golang.org/doc/go_spec.html#If_statements
 	// https://developer.mozilla.org/en/JavaScript/Reference/Statements/if...else
@@ -281,7 +294,22 @@ func (tr *transform) getStatement(stmt ast.Stmt) {
 	//  TokPos token.Pos   // position of Tok
 	//  Tok    token.Token // INC or DEC
 	case *ast.IncDecStmt:
-		tr.WriteString(tr.getExpression(typ.X).String() + typ.Tok.String())
+		x := tr.getTarget(typ.X)
+
+		if t := tr.typeOf(typ.X); isLowered(token.ADD, t) {
+			op := token.ADD
+			if typ.Tok == token.DEC {
+				op = token.SUB
+			}
+			one := tr.newExpression(nil)
+			lit, _ := tr.constLiteral(typ.TokPos, t, exact.MakeInt64(1))
+			one.WriteString(lit)
+
+			js, _ := tr.binaryOp(op, t, tr.getExpression(typ.X), one, nil)
+			tr.WriteString(x.String() + SP + "=" + SP + js)
+		} else {
+			tr.WriteString(x.String() + typ.Tok.String())
+		}
 
 		if tr.skipSemicolon {
 			tr.skipSemicolon = false
@@ -300,10 +328,13 @@ func (tr *transform) getStatement(stmt ast.Stmt) {
 	//  X          Expr        // value to range over
 	//  Body       *BlockStmt
 	case *ast.RangeStmt:
-		expr := tr.getExpression(typ.X).String()
-		key := tr.getExpression(typ.Key).String()
+		x := tr.getExpression(typ.X)
+		expr := x.String()
+		key := BLANK // for range x
+		if typ.Key != nil {
+			key = tr.getExpression(typ.Key).String()
+		}
 		value := ""
-		isMap := false
 
 		if typ.Value != nil {
 			value = tr.getExpression(typ.Value).String()
@@ -313,21 +344,82 @@ func (tr *transform) getStatement(stmt ast.Stmt) {
 			}
 		}
 
-		tr.WriteString(fmt.Sprintf("for%s(%s in %s", SP, key, expr))
-		if tr.isType(mapType, expr) {
-			tr.WriteString(".m")
-			isMap = true
+		// The index is the variable of the loop, so it can not be a box.
+		if !tr.isType(mapType, typ.X, expr) && tr.defValue(typ.Key, key) != key {
+			tr.addError(typ.Key.Pos(), "unsupported-address",
+				"address of the index of a range clause")
+			tr.hasError = true
+			break
 		}
-		tr.WriteString(")" + SP)
 
-		if typ.Value != nil {
-			tr.WriteString(fmt.Sprintf("{%s=%s", SP+value+SP, SP+expr))
-			if isMap {
-				tr.WriteString(".get(" + key + ")[0];")
-			} else {
-				tr.WriteString("[" + key + "];")
+		// The runes of a string are decoded from its bytes.
+		if isString(tr.typeOf(typ.X)) {
+			rune := fmt.Sprintf("_r%d", tr.blockId) // the rune and its size
+			init := "var "
+
+			if key == "_" {
+				key = fmt.Sprintf("_i%d", tr.blockId)
+			}
+			// The string is evaluated once.
+			if _, ok := typ.X.(*ast.Ident); !ok {
+				str := fmt.Sprintf("_s%d", tr.blockId)
+				init += str + SP + "=" + SP + expr + "," + SP
+				expr = str
+			}
+
+			tr.WriteString(fmt.Sprintf("for%s(%s,%s;%s<%s;%s+=%s[1])%s{%s=%sg.DecodeRune(%s,%s);",
+				SP, init+key+SP+"="+SP+"0", SP+rune, SP+key+SP, SP+expr+".length",
+				SP+key+SP, SP+rune, SP, SP+rune+SP, SP, expr, SP+key))
+			if typ.Value != nil {
+				tr.WriteString(fmt.Sprintf("%s=%s;", SP+value+SP, SP+tr.defValue(typ.Value, rune+"[0]")))
+			}
+
+			tr.skipLbrace = true
+			tr.getStatement(typ.Body)
+			break
+		}
+
+		// The entries of a map are got from its Map, where the deleted ones
+		// are not reached.
+		if tr.isType(mapType, typ.X, expr) {
+			entry := fmt.Sprintf("_e%d", tr.blockId) // the key and the value
+
+			tr.WriteString(fmt.Sprintf("for%s(var %s of %s.f.values())%s{",
+				SP, entry, tr.readMap(typ.X, paren(expr, x.prec, 11), 0), SP))
+
+			if key != "_" {
+				if typ.Tok == token.DEFINE {
+					tr.WriteString(SP + "var")
+				}
+				tr.WriteString(fmt.Sprintf(" %s=%s;", key+SP,
+					SP+tr.defValue(typ.Key, tr.rangeCopy(typ.Key, entry+"[0]"))))
+			}
+			if typ.Value != nil {
+				tr.WriteString(fmt.Sprintf("%s=%s;", SP+value+SP,
+					SP+tr.defValue(typ.Value, tr.rangeCopy(typ.Value, entry+"[1]"))))
 			}
 
+			tr.skipLbrace = true
+			tr.getStatement(typ.Body)
+			break
+		}
+
+		if tr.typeOf(typ.X) != nil && tr.isType(sliceType, typ.X, expr) {
+			expr += ".f"
+		}
+
+		// The variable is declared, since modules are in strict mode.
+		decl := ""
+		if typ.Tok == token.DEFINE || key == BLANK {
+			decl = "var "
+		}
+		tr.WriteString(fmt.Sprintf("for%s(%s in %s)%s", SP, decl+key, expr, SP))
+
+		if typ.Value != nil {
+			elem := expr + "[" + key + "]"
+			tr.WriteString(fmt.Sprintf("{%s=%s;", SP+value+SP,
+				SP+tr.defValue(typ.Value, tr.rangeCopy(typ.Value, elem))))
+
 			tr.skipLbrace = true
 		}
 
@@ -354,16 +446,16 @@ func (tr *transform) getStatement(stmt ast.Stmt) {
 		// Multiple values
 		if len(typ.Results) != 1 {
 			results := ""
-			for i, v := range typ.Results {
+			for i := range typ.Results {
 				if i != 0 {
 					results += "," + SP
 				}
-				results += tr.getExpression(v).String()
+				results += tr.resultValue(i, typ.Results)
 			}
 
 			tr.WriteString("return [" + results + "];")
 		} else {
-			tr.WriteString("return " + tr.getExpression(typ.Results[0]).String() + ";")
+			tr.WriteString("return " + tr.resultValue(0, typ.Results) + ";")
 		}
 
 	// http://golang.org/doc/go_spec.html#Switch_statements
@@ -387,7 +479,19 @@ func (tr *transform) getStatement(stmt ast.Stmt) {
 		}
 
 		tr.WriteString(fmt.Sprintf("switch%s(%s)%s", SP, tag, SP))
+
+		typeSwitch := tr.typeSwitch
+		tr.typeSwitch = ""
 		tr.getStatement(typ.Body)
+		tr.typeSwitch = typeSwitch
+
+	// godoc go/ast TypeSwitchStmt
+	//  Switch token.Pos  // position of "switch" keyword
+	//  Init   Stmt       // initialization statement; or nil
+	//  Assign Stmt       // x := y.(type) or y.(type)
+	//  Body   *BlockStmt // CaseClauses only
+	case *ast.TypeSwitchStmt:
+		tr.writeTypeSwitch(typ)
 
 	// === Not supported
 
@@ -397,7 +501,7 @@ func (tr *transform) getStatement(stmt ast.Stmt) {
 	//  Defer token.Pos // position of "defer" keyword
 	//  Call  *CallExpr
 	case *ast.DeferStmt:
-		tr.addError("%s: defer directive", tr.fset.Position(typ.Defer))
+		tr.addError(typ.Defer, "unsupported-defer", "defer directive")
 
 	// http://golang.org/doc/go_spec.html#Labeled_statements
 	// https://developer.mozilla.org/en/JavaScript/Reference/Statements/label
@@ -407,9 +511,10 @@ func (tr *transform) getStatement(stmt ast.Stmt) {
 	//  Colon token.Pos // position of ":"
 	//  Stmt  Stmt
 	case *ast.LabeledStmt:
-		tr.addError("%s: use of label", tr.fset.Position(typ.Pos()))
+		tr.addError(typ.Pos(), "unsupported-label", "use of label")
 
 	default:
-		panic(fmt.Sprintf("unimplemented: %T", stmt))
+		tr.addError(stmt.Pos(), "unsupported-statement",
+			"statement of kind %s", nodeKind(stmt))
 	}
 }
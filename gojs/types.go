@@ -0,0 +1,220 @@
+// Copyright 2012  The "GoScript" Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gojs
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+/*
+## Types
+
+Before of the translation, the package is checked by "go/types" so the
+decisions which depend on the type of an expression are based on the
+information got by the checker.
+
+The errors found by the checker are reported like the ones of the Go compiler,
+and the package is not transformed.
+
+When it is being transformed the gojs's package (which is not valid Go), the
+types are unknown; then, it is used the information stored at declaring the
+variables.
+*/
+
+// Checks the types of the package, storing the information got.
+// The errors found are reported like the Go compiler, sorted by position.
+func (tr *transform) checkTypes(files []*ast.File) {
+	if tr.opts.Bootstrap {
+		return
+	}
+
+	tr.info = &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		Implicits:  make(map[ast.Node]types.Object),
+	}
+
+	imp := &libImporter{importer.ForCompiler(tr.fset, "gc", nil), tr.opts.Importer}
+	if imp.pkg == nil {
+		imp.pkg = importer.ForCompiler(tr.fset, "source", nil)
+	}
+
+	errList := make([]types.Error, 0)
+	conf := types.Config{
+		Importer: imp,
+		Error: func(err error) {
+			// The continuation lines, like "other declaration of", are skipped.
+			if e, ok := err.(types.Error); ok && !strings.HasPrefix(e.Msg, "\t") {
+				errList = append(errList, e)
+			}
+		},
+	}
+	conf.Check(files[0].Name.Name, tr.fset, files, tr.info)
+	tr.funcLits = funcLitVars(files, tr.info)
+	tr.addressed = addressedVars(files, tr.info)
+	tr.pkgNames = importNames(files, tr.info)
+
+	sort.SliceStable(errList, func(i, j int) bool {
+		return errList[i].Pos < errList[j].Pos
+	})
+	for _, e := range errList {
+		// The diagnostics have only a line.
+		msg := e.Msg
+		if i := strings.Index(msg, "\n"); i != -1 {
+			msg = msg[:i]
+		}
+		tr.addError(e.Pos, typeErrorCode(msg), "%s", msg)
+	}
+}
+
+// Returns the code of an error found at checking the types.
+func typeErrorCode(msg string) string {
+	switch {
+	case strings.HasPrefix(msg, "declared and not used"):
+		return "unused-variable"
+	case strings.Contains(msg, " imported ") && strings.HasSuffix(msg, " and not used"):
+		return "unused-import"
+	case strings.Contains(msg, "overflows"):
+		return "constant-overflow"
+	}
+	return "type"
+}
+
+// libImporter imports the packages of the core library by "std". The rest of
+// packages, transformed by GoScript, are imported by "pkg".
+type libImporter struct {
+	std types.Importer
+	pkg types.Importer
+}
+
+func (imp *libImporter) Import(path string) (*types.Package, error) {
+	// The packages which can not be transformed are reported later.
+	if !strings.Contains(path, ".") {
+		return imp.std.Import(path)
+	}
+	return imp.pkg.Import(path)
+}
+
+// Returns the type of the expression; or nil if it is unknown.
+func (tr *transform) typeOf(expr ast.Expr) types.Type {
+	if tr.info == nil || expr == nil {
+		return nil
+	}
+
+	var typ types.Type
+
+	if tv, ok := tr.info.Types[expr]; ok {
+		typ = tv.Type
+	} else if ident, ok := expr.(*ast.Ident); ok {
+		if obj := tr.info.ObjectOf(ident); obj != nil {
+			typ = obj.Type()
+		}
+	}
+
+	if typ == nil || typ == types.Typ[types.Invalid] {
+		return nil
+	}
+	return typ
+}
+
+// Returns the kind of data of the type.
+func dataTypeOf(typ types.Type) dataType {
+	switch typ.Underlying().(type) {
+	case *types.Map:
+		return mapType
+	case *types.Pointer:
+		return pointerType
+	case *types.Slice:
+		return sliceType
+	}
+	return otherType
+}
+
+// Reports whether the values of the type have to be compared by their
+// content, instead of by their identity.
+func isComposite(typ types.Type) bool {
+	switch typ.Underlying().(type) {
+	case *types.Array, *types.Struct:
+		return true
+	}
+	return false
+}
+
+// Reports whether the type is a pointer to a struct or an array; the pointer
+// is the value itself, since they are objects in JavaScript.
+func isCompositePointer(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+	p, ok := typ.Underlying().(*types.Pointer)
+	return ok && isComposite(p.Elem())
+}
+
+// Reports whether the type is a string.
+func isString(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+	b, ok := typ.Underlying().(*types.Basic)
+	return ok && b.Info()&types.IsString != 0
+}
+
+// Returns the kind of the elements of a slice of bytes or of runes, which are
+// converted to strings; or types.Invalid.
+func byteSliceOf(typ types.Type) types.BasicKind {
+	if typ == nil {
+		return types.Invalid
+	}
+	if s, ok := typ.Underlying().(*types.Slice); ok {
+		if b, ok := s.Elem().Underlying().(*types.Basic); ok {
+			switch b.Kind() {
+			case types.Uint8, types.Int32:
+				return b.Kind()
+			}
+		}
+	}
+	return types.Invalid
+}
+
+// Reports whether the type is an array.
+func isArray(typ types.Type) bool {
+	_, ok := typ.Underlying().(*types.Array)
+	return ok
+}
+
+// Returns the type of the element "i" of a composite literal of type "typ";
+// or nil if it is unknown.
+func eltType(typ types.Type, i int) types.Type {
+	if typ == nil {
+		return nil
+	}
+	switch t := typ.Underlying().(type) {
+	case *types.Array:
+		return t.Elem()
+	case *types.Slice:
+		return t.Elem()
+	case *types.Struct:
+		if i < t.NumFields() {
+			return t.Field(i).Type()
+		}
+	}
+	return nil
+}
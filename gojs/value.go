@@ -0,0 +1,398 @@
+// Copyright 2012  The "GoScript" Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gojs
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+/*
+## Values
+
+The structs and the arrays are objects in JavaScript, which are assigned by
+reference. Then, they are copied wherever Go copies a value: at assigning,
+passing arguments, returning, ranging, and building composite literals.
+
+Each struct has a method "clone", which copies its fields; the fields of type
+struct or array are cloned too, but the pointers, slices and maps are shared:
+
+	a := b      =>  var a = b.clone();
+	s[i] = arr  =>  s.f[i] = arr.slice();
+
+The copy is skipped when the value is new, like a composite literal or the
+result of a function, since nobody else could see it; or when it is a local
+variable returned, which is not aliased by a pointer, a slice or a function
+literal. The arguments are copied by the function which receives them, and
+only if it could modify them; like the receiver of a method.
+
+The equality depends on the type of the values. The basic types are compared
+by "===", like the pointers, maps and channels, which are compared by identity.
+Each comparable struct has a method "eq", which compares its fields; the
+arrays are compared by their elements, and the interfaces by their dynamic
+type and value through "g.Equal":
+
+	p == q      =>  p.eq(q)
+	a != b      =>  !g.ArrayEq(a, b, function(a, b) { return a === b; })
+*/
+
+// Returns the expression "js", whose operator at the top has precedence
+// "prec", like a copy of a value of type "typ".
+func (tr *transform) cloneOf(typ types.Type, js string, prec int) string {
+	switch t := typ.Underlying().(type) {
+	case *types.Struct:
+		// Only the named types have a constructor, with the method.
+		if _, ok := typ.(*types.Named); ok {
+			return paren(js, prec, 11) + ".clone()"
+		}
+	case *types.Array:
+		if isComposite(t.Elem()) {
+			return fmt.Sprintf("%s.map(function(v)%s{%sreturn %s;%s})",
+				paren(js, prec, 11), SP, SP, tr.cloneOf(t.Elem(), "v", 0), SP)
+		}
+		return paren(js, prec, 11) + ".slice()"
+	}
+	return js
+}
+
+// Returns the expression "js", which is the translation of "expr", copied if
+// it is a shared value.
+func (tr *transform) copyValue(expr ast.Expr, js string, prec int) string {
+	if tr.isShared(expr) {
+		return tr.cloneOf(tr.typeOf(expr), js, prec)
+	}
+	return js
+}
+
+// Returns the result "i" of a return statement with the results "list":
+// copied if it is a shared value, and boxed if it is returned like an
+// interface.
+func (tr *transform) resultValue(i int, list []ast.Expr) string {
+	x := tr.getExpression(list[i])
+	to := tr.resultType(i, len(list))
+
+	if !tr.isOwned(list[i], list) {
+		return tr.valueTo(to, list[i], x.String(), x.prec)
+	}
+	if from := tr.typeOf(list[i]); isBoxed(to, from) {
+		return tr.box(from, x.String())
+	}
+	return x.String()
+}
+
+// Reports whether the expression, one of the results "list" of a return
+// statement, is a variable whose value can not be seen from another place
+// after returning, so it is not copied: a local variable of the actual
+// function, a named result, or a parameter which has been copied at the start;
+// which is not aliased, nor used by other result.
+func (tr *transform) isOwned(expr ast.Expr, list []ast.Expr) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok || tr.info == nil || tr.funcBody == nil {
+		return false
+	}
+	v, ok := tr.info.Uses[ident].(*types.Var)
+	if !ok || v.Pos() < tr.funcType.Pos() || v.Pos() >= tr.funcBody.End() {
+		return false
+	}
+
+	// The receiver and the parameters are copied if they could be modified.
+	results := tr.funcType.Results
+	isResult := results != nil && v.Pos() >= results.Pos() && v.Pos() < results.End()
+
+	if v.Pos() < tr.funcBody.Pos() && !isResult && !tr.isModified(tr.funcBody, ident.Name) {
+		return false
+	}
+
+	for _, r := range list {
+		if r != expr && rootName(r) == ident.Name {
+			return false
+		}
+	}
+	return !tr.isAliased(tr.funcBody, ident.Name)
+}
+
+// Returns the element "js" got at ranging, copied if the variable "v" is a
+// struct or an array.
+func (tr *transform) rangeCopy(v ast.Expr, js string) string {
+	if t := tr.typeOf(v); t != nil && isComposite(t) {
+		return tr.cloneOf(t, js, 0)
+	}
+	return js
+}
+
+// Reports whether the expression is a struct or an array which could be seen
+// from another place, so it has to be copied.
+func (tr *transform) isShared(expr ast.Expr) bool {
+	typ := tr.typeOf(expr)
+	return typ != nil && isComposite(typ) && !tr.isFresh(expr)
+}
+
+// Reports whether the expression builds a new value, which has not to be
+// copied: a composite literal, or the result of a function.
+func (tr *transform) isFresh(expr ast.Expr) bool {
+	switch t := expr.(type) {
+	case *ast.CompositeLit:
+		return true
+	case *ast.ParenExpr:
+		return tr.isFresh(t.X)
+	case *ast.CallExpr:
+		// A conversion returns the same value.
+		if tv, ok := tr.info.Types[t.Fun]; ok && tv.IsType() {
+			return len(t.Args) == 1 && tr.isFresh(t.Args[0])
+		}
+		return true
+	}
+	return false
+}
+
+// Returns the method "clone" of the struct declared with the name "name",
+// which returns a copy of the value; or an empty string if its type is
+// unknown.
+func (tr *transform) cloneMethod(name *ast.Ident) string {
+	if tr.info == nil {
+		return ""
+	}
+	obj, ok := tr.info.Defs[name]
+	if !ok || obj == nil {
+		return ""
+	}
+	st, ok := obj.Type().Underlying().(*types.Struct)
+	if !ok {
+		return ""
+	}
+
+	fields := ""
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		if f.Name() == "_" {
+			continue
+		}
+		fields += fmt.Sprintf("c.%s%s=%s;%s", f.Name(), SP,
+			SP+tr.cloneOf(f.Type(), "this."+f.Name(), 0), SP)
+	}
+
+	return fmt.Sprintf("%s.prototype.clone%s=%sfunction()%s{%svar c%s=%sObject.create(%s.prototype);%sreturn c;%s};",
+		name.Name, SP, SP, SP, SP, SP, SP, name.Name, SP+fields, SP)
+}
+
+// Returns the copies of the parameters of type struct or array, which are
+// done at the start of the function, if the values could be modified.
+// Then, the arguments are passed without copying them.
+func (tr *transform) copyParams(typ *ast.FuncType, body *ast.BlockStmt) string {
+	copies := ""
+
+	for _, field := range typ.Params.List {
+		t := tr.typeOf(field.Type)
+		if t == nil || !isComposite(t) {
+			continue
+		}
+		for _, v := range field.Names {
+			if tr.isModified(body, v.Name) {
+				copies += fmt.Sprintf("%s=%s;", v.Name+SP, SP+tr.cloneOf(t, v.Name, 0))
+			}
+		}
+	}
+	return copies
+}
+
+// == Equality
+//
+
+// Returns the comparison "x == y", or "x != y" if "op" is token.NEQ, of
+// values of types "xType" and "yType"; and its precedence.
+func (tr *transform) equalOp(op token.Token, xType, yType types.Type, x, y *expression) (string, int) {
+	xJS, yJS := x.String(), y.String()
+	xPrec, yPrec := x.prec, y.prec
+
+	// A value is compared with an interface by its dynamic type and value.
+	if types.IsInterface(xType) || types.IsInterface(yType) {
+		if isBoxed(yType, xType) {
+			xJS, xPrec = tr.box(xType, xJS), 0
+		} else if isBoxed(xType, yType) {
+			yJS, yPrec = tr.box(yType, yJS), 0
+		}
+		xType = types.NewInterfaceType(nil, nil)
+	}
+	return tr.equalOf(xType, xJS, yJS, xPrec, yPrec, op == token.NEQ)
+}
+
+// Returns the comparison "x == y", or "x != y" if "not", of values of type
+// "typ", whose operators at the top have precedence "xPrec" and "yPrec"; and
+// its precedence.
+func (tr *transform) equalOf(typ types.Type, x, y string, xPrec, yPrec int, not bool) (string, int) {
+	js := ""
+	op := "==="
+	if not {
+		op = "!=="
+	}
+
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		if isObject(t) {
+			js = fmt.Sprintf("%s.eq(%s)", paren(x, xPrec, 11), y)
+		}
+	case *types.Struct:
+		if _, ok := typ.(*types.Named); ok {
+			js = fmt.Sprintf("%s.eq(%s)", paren(x, xPrec, 11), y)
+		} else { // without constructor
+			return fmt.Sprintf("JSON.stringify(%s)%sJSON.stringify(%s)", x, SP+op+SP, y),
+				jsPrecedence[op]
+		}
+	case *types.Array:
+		eq, _ := tr.equalOf(t.Elem(), "a", "b", 0, 0, false)
+		js = fmt.Sprintf("g.ArrayEq(%s,%s,%sfunction(a,%sb)%s{%sreturn %s;%s})",
+			x, SP+y, SP, SP, SP, SP, eq, SP)
+	case *types.Interface:
+		js = fmt.Sprintf("g.Equal(%s,%s)", x, SP+y)
+	}
+
+	if js != "" {
+		if not {
+			return "!" + js, 11
+		}
+		return js, 0
+	}
+
+	// The pointers, maps, channels and functions are compared by identity.
+	prec := jsPrecedence[op]
+	return paren(x, xPrec, prec-1) + SP + op + SP + paren(y, yPrec, prec), prec
+}
+
+// Returns the method "eq" of the struct declared with the name "name", which
+// compares its fields with the ones of other value; or an empty string if its
+// type is unknown or it is not comparable.
+func (tr *transform) eqMethod(name *ast.Ident) string {
+	if tr.info == nil {
+		return ""
+	}
+	obj, ok := tr.info.Defs[name]
+	if !ok || obj == nil || !types.Comparable(obj.Type()) {
+		return ""
+	}
+	st, ok := obj.Type().Underlying().(*types.Struct)
+	if !ok {
+		return ""
+	}
+
+	fields := ""
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		if f.Name() == "_" { // the blank fields are not compared
+			continue
+		}
+		if fields != "" {
+			fields += SP + "&&" + SP
+		}
+		eq, prec := tr.equalOf(f.Type(), "this."+f.Name(), "y."+f.Name(), 0, 0, false)
+		fields += paren(eq, prec, jsPrecedence["&&"])
+	}
+	if fields == "" {
+		fields = "true"
+	}
+
+	return fmt.Sprintf("%s.prototype.eq%s=%sfunction(y)%s{%sreturn %s;%s};",
+		name.Name, SP, SP, SP, SP, fields, SP)
+}
+
+// Reports whether the variable "name" could be modified in the block: by an
+// assignment, an increment, ranging over it, or if it is aliased.
+func (tr *transform) isModified(block *ast.BlockStmt, name string) bool {
+	found := false
+	modifies := func(expr ast.Expr) {
+		if expr != nil && rootName(expr) == name {
+			found = true
+		}
+	}
+
+	ast.Inspect(block, func(node ast.Node) bool {
+		switch t := node.(type) {
+		case *ast.AssignStmt:
+			for _, v := range t.Lhs {
+				modifies(v)
+			}
+		case *ast.IncDecStmt:
+			modifies(t.X)
+		case *ast.RangeStmt:
+			modifies(t.Key)
+			modifies(t.Value)
+		}
+		return !found
+	})
+	return found || tr.isAliased(block, name)
+}
+
+// Reports whether the value of the variable "name" could be reached from
+// another place in the block: by taking its address, slicing it, or using a
+// method with a pointer receiver. A function literal which uses it could see
+// the changes done later by the caller.
+func (tr *transform) isAliased(block *ast.BlockStmt, name string) bool {
+	found := false
+	aliases := func(expr ast.Expr) {
+		if rootName(expr) == name {
+			found = true
+		}
+	}
+
+	ast.Inspect(block, func(node ast.Node) bool {
+		switch t := node.(type) {
+		case *ast.FuncLit:
+			ast.Inspect(t.Body, func(node ast.Node) bool {
+				if ident, ok := node.(*ast.Ident); ok && ident.Name == name {
+					found = true
+				}
+				return !found
+			})
+		case *ast.UnaryExpr:
+			if t.Op == token.AND {
+				aliases(t.X)
+			}
+		case *ast.SliceExpr:
+			aliases(t.X)
+		case *ast.SelectorExpr: // method called, or bound
+			if tr.info == nil {
+				break
+			}
+			if s, ok := tr.info.Selections[t]; ok && s.Kind() == types.MethodVal {
+				recv := s.Obj().Type().(*types.Signature).Recv()
+				if _, ok := recv.Type().(*types.Pointer); ok {
+					aliases(t.X)
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// Returns the name of the variable whose value is accessed by the
+// expression, through fields and indexes; or an empty string.
+func rootName(expr ast.Expr) string {
+	for {
+		switch t := expr.(type) {
+		case *ast.Ident:
+			return t.Name
+		case *ast.SelectorExpr:
+			expr = t.X
+		case *ast.IndexExpr:
+			expr = t.X
+		case *ast.ParenExpr:
+			expr = t.X
+		default:
+			return ""
+		}
+	}
+}
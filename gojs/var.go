@@ -15,15 +15,21 @@
 package gojs
 
 import (
+	"bytes"
 	"fmt"
 	"go/ast"
+	exact "go/constant"
 	"go/token"
+	"go/types"
+	"math"
 	"strconv"
 	"strings"
 )
 
 // Constants
 //
+// The values are calculated at compiling, with exact precision.
+//
 // http://golang.org/doc/go_spec.html#Constant_declarations
 // https://developer.mozilla.org/en/JavaScript/Reference/Statements/const
 func (tr *transform) getConst(pos token.Pos, spec []ast.Spec, isGlobal bool) {
@@ -31,10 +37,19 @@ func (tr *transform) getConst(pos token.Pos, spec []ast.Spec, isGlobal bool) {
 	isMultipleLine := false
 	tr.isConst = true
 
+	export := "" // keyword to export in modules
+	if isGlobal {
+		names := make([]*ast.Ident, 0)
+		for _, s := range spec {
+			names = append(names, s.(*ast.ValueSpec).Names...)
+		}
+		export = tr.exportAt(names...)
+	}
+
 	if len(spec) > 1 {
 		isMultipleLine = true
 		tr.addLine(pos)
-		tr.WriteString("const ")
+		tr.WriteString(export + "const ")
 	}
 
 	// godoc go/ast ValueSpec
@@ -60,9 +75,16 @@ func (tr *transform) getConst(pos token.Pos, spec []ast.Spec, isGlobal bool) {
 				continue
 			}
 
+			if tr.info != nil && isHiddenConst(tr.info.Defs[ident]) {
+				iotaExpr = append(iotaExpr, "")
+				continue
+			}
+
 			value := strconv.Itoa(ident.Obj.Data.(int)) // possible value of iota
 
-			if vSpec.Values != nil {
+			if val, ok := tr.constValue(ident); ok {
+				value = val
+			} else if vSpec.Values != nil {
 				v := vSpec.Values[i]
 
 				expr := tr.getExpression(v)
@@ -87,6 +109,7 @@ func (tr *transform) getConst(pos token.Pos, spec []ast.Spec, isGlobal bool) {
 			if isGlobal {
 				tr.addIfExported(ident)
 			}
+			name := tr.mark(ident.Pos(), ident.Name) + ident.Name
 
 			// === Write
 			if isFirst {
@@ -96,13 +119,13 @@ func (tr *transform) getConst(pos token.Pos, spec []ast.Spec, isGlobal bool) {
 					tr.WriteString(strings.Repeat(TAB, tr.tabLevel))
 				}
 				if isMultipleLine {
-					tr.WriteString(ident.Name+SP + "=" + SP+value)
+					tr.WriteString(name+SP + "=" + SP+value)
 				} else {
-					tr.WriteString(fmt.Sprintf("const %s=%s", ident.Name+SP, SP+value))
+					tr.WriteString(fmt.Sprintf("%sconst %s=%s", export, name+SP, SP+value))
 				}
 
 			} else {
-				tr.WriteString(fmt.Sprintf(",%s=%s", SP+ident.Name+SP, SP+value))
+				tr.WriteString(fmt.Sprintf(",%s=%s", SP+name+SP, SP+value))
 			}
 		}
 
@@ -123,6 +146,122 @@ func (tr *transform) getConst(pos token.Pos, spec []ast.Spec, isGlobal bool) {
 	tr.isConst = false
 }
 
+// Returns the value of the constant "ident", calculated by the type checker,
+// like a JavaScript literal; and a boolean to indicate if it is known.
+func (tr *transform) constValue(ident *ast.Ident) (string, bool) {
+	if tr.info == nil {
+		return "", false
+	}
+	obj, ok := tr.info.Defs[ident].(*types.Const)
+	if !ok {
+		return "", false
+	}
+	return tr.constLiteral(ident.Pos(), obj.Type(), obj.Val())
+}
+
+// Returns the constant value "val" of type "typ", found at "pos", like a
+// JavaScript literal; and a boolean to indicate if it is known.
+// The numbers have to be represented exactly by a JavaScript number, but the
+// integers of 64 bits.
+func (tr *transform) constLiteral(pos token.Pos, typ types.Type, val exact.Value) (string, bool) {
+	if !isExactConst(typ, val) {
+		tr.addError(pos, "constant-precision",
+			"constant %s is not represented exactly by a JavaScript number", val)
+		return "", true
+	}
+
+	if b := numberOf(typ); isComplex(b) {
+		val = exact.ToComplex(val)
+		re, _ := exact.Float64Val(exact.Real(val))
+		im, _ := exact.Float64Val(exact.Imag(val))
+		return literalComplex(b, re, im), true
+	}
+	if b := numberOf(typ); is64(b) {
+		val = exact.ToInt(val)
+		if n, ok := exact.Int64Val(val); ok {
+			return literal64(b, uint64(n)), true
+		}
+		n, _ := exact.Uint64Val(val)
+		return literal64(b, n), true
+	}
+
+	switch val.Kind() {
+	case exact.Bool:
+		return strconv.FormatBool(exact.BoolVal(val)), true
+	case exact.String:
+		return quoteJS(exact.StringVal(val)), true
+	case exact.Int:
+		return val.ExactString(), true
+	case exact.Float:
+		f, _ := exact.Float64Val(val)
+		return strconv.FormatFloat(f, 'g', -1, 64), true
+	}
+	return "", false
+}
+
+// Reports whether the constant value "val" of type "typ" is represented by a
+// JavaScript number: the integers have to be exact, but the ones of 64 bits
+// which are objects; and the floats have to be finite.
+func isExactConst(typ types.Type, val exact.Value) bool {
+	if b := numberOf(typ); isComplex(b) || is64(b) {
+		return true
+	}
+
+	switch val.Kind() {
+	case exact.Int:
+		_, isExact := exact.Float64Val(val)
+		return isExact
+	case exact.Float:
+		f, _ := exact.Float64Val(val)
+		return !math.IsInf(f, 0)
+	}
+	return true
+}
+
+// Reports whether "obj" is an untyped constant which is not represented by a
+// JavaScript number, so it is not declared; its uses are constant
+// expressions, whose values are checked where they are written.
+func isHiddenConst(obj types.Object) bool {
+	c, ok := obj.(*types.Const)
+	if !ok {
+		return false
+	}
+	if b, ok := c.Type().(*types.Basic); !ok || b.Info()&types.IsUntyped == 0 {
+		return false
+	}
+	return !isExactConst(c.Type(), c.Val())
+}
+
+// Returns the string quoted like a JavaScript literal.
+// Each byte is a character, so the bytes out of ASCII are escaped.
+func quoteJS(s string) string {
+	var buf bytes.Buffer
+	buf.WriteByte('"')
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"', '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if c < 0x20 || c >= 0x7f {
+				fmt.Fprintf(&buf, `\x%02x`, c)
+			} else {
+				buf.WriteByte(c)
+			}
+		}
+	}
+
+	buf.WriteByte('"')
+	return buf.String()
+}
+
 // Variables
 //
 // http://golang.org/doc/go_spec.html#Variable_declarations
@@ -150,6 +289,9 @@ func (tr *transform) getVar(spec []ast.Spec, isGlobal bool) {
 		}
 
 		tr.addLine(vSpec.Pos())
+		if isGlobal {
+			tr.WriteString(tr.exportAt(vSpec.Names...))
+		}
 		// Pass token.DEFINE to know that it is a new variable
 		tr.writeVar(vSpec.Names, vSpec.Values, vSpec.Type, token.DEFINE,
 			isGlobal, isMultipleLine)
@@ -174,12 +316,6 @@ func (tr *transform) getType(spec []ast.Spec, isGlobal bool) {
 		}
 
 		switch typ := tSpec.Type.(type) {
-		// godoc go/ast Ident
-		//  NamePos token.Pos // identifier position
-		//  Name    string    // identifier name
-		//  Obj     *Object   // denoted object; or nil
-		case *ast.Ident:
-
 		// godoc go/ast StructType
 		//  Struct     token.Pos  // position of "struct" keyword
 		//  Fields     *FieldList // list of field declarations
@@ -191,7 +327,9 @@ func (tr *transform) getType(spec []ast.Spec, isGlobal bool) {
 		//  Closing token.Pos // position of closing parenthesis/brace, if any
 		case *ast.StructType:
 			if typ.Incomplete {
-				panic("list of fields incomplete ???")
+				tr.addError(typ.Pos(), "unsupported-struct",
+					"list of fields incomplete")
+				continue
 			}
 
 			var fieldNames, fieldLines, fieldsInit string
@@ -209,28 +347,30 @@ func (tr *transform) getType(spec []ast.Spec, isGlobal bool) {
 			//  Tag     *BasicLit     // field tag; or nil
 			//  Comment *CommentGroup // line comments; or nil
 			for _, field := range typ.Fields.List {
-				isPointer := false
-
-				if _, ok := field.Type.(*ast.FuncType); ok {
-					tr.addError("%s: function type in struct",
-						tr.fset.Position(field.Pos()))
+				// Type checking
+				if tr.getExpression(field.Type).hasError {
 					continue
 				}
-				if field.Names == nil {
-					tr.addError("%s: anonymous field in struct",
-						tr.fset.Position(field.Pos()))
+
+				if field.Names == nil && embeddedName(field.Type) == nil {
+					tr.addError(field.Type.Pos(), "unsupported-embedded",
+						"embedded field of kind %s", nodeKind(field.Type))
 					continue
 				}
-				// Type checking
-				if expr := tr.getExpression(field.Type); expr.hasError {
-					continue
-				} else if expr.isPointer {
-					isPointer = true
+
+				var zero string
+				if t := tr.typeOf(field.Type); t != nil && isArray(t) {
+					zero, _ = tr.zeroOf(true, t) // like an expression
+				} else {
+					zero, _ = tr.zeroValue(true, field.Type)
 				}
 
-				zero, _ := tr.zeroValue(true, field.Type)
+				names := field.Names
+				if names == nil { // embedded field
+					names = []*ast.Ident{embeddedName(field.Type)}
+				}
 
-				for _, v := range field.Names {
+				for _, v := range names {
 					name := v.Name
 					if name == "_" {
 						continue
@@ -254,11 +394,7 @@ func (tr *transform) getType(spec []ast.Spec, isGlobal bool) {
 						fieldLines += SP
 					}
 
-					if !isPointer {
-						fieldLines += fmt.Sprintf("this.%s=%s;", name, name)
-					} else {
-						fieldLines += fmt.Sprintf("this.%s={p:%s};", name, name)
-					}
+					fieldLines += fmt.Sprintf("this.%s=%s;", name, name)
 
 					posOldField = posNewField
 					// ===
@@ -286,8 +422,24 @@ func (tr *transform) getType(spec []ast.Spec, isGlobal bool) {
 
 			// Write
 			tr.addLine(tSpec.Pos())
-			tr.WriteString(fmt.Sprintf(
-				"function %s(%s)%s{%s}", tSpec.Name, fieldNames, SP, fieldLines))
+			if isGlobal {
+				tr.WriteString(tr.exportAt(tSpec.Name))
+			}
+			tr.WriteString(fmt.Sprintf("function %s(%s)%s{%s}",
+				tr.mark(tSpec.Name.Pos(), tSpec.Name.Name)+tSpec.Name.Name,
+				fieldNames, SP, fieldLines))
+			if clone := tr.cloneMethod(tSpec.Name); clone != "" {
+				tr.WriteString(SP + clone)
+			}
+			if eq := tr.eqMethod(tSpec.Name); eq != "" {
+				tr.WriteString(SP + eq)
+			}
+			if decl := tr.typeDecl(tSpec.Name, isGlobal); decl != "" {
+				tr.WriteString(SP + decl)
+			}
+			if promoted := tr.promotedMethods(tSpec.Name); promoted != "" {
+				tr.WriteString(SP + promoted)
+			}
 /*			tr.WriteString(fmt.Sprintf("function %s(%s)%s{%sthis._z=%q;%s}",
 				tSpec.Name, fieldNames, SP,
 				SP, fieldsInit, fieldLines))
@@ -297,10 +449,14 @@ func (tr *transform) getType(spec []ast.Spec, isGlobal bool) {
 
 			tr.line += posNewField - firstPos // update the global position
 
+		// The types which are not structs only have their descriptor, so their
+		// values are not wrapped; their methods are functions.
 		default:
-			tr.addLine(tSpec.Pos())
-			tr.WriteString(fmt.Sprintf("function %s(t)%s{%sthis.t=t;%s}",
-				tSpec.Name, SP, SP, SP))
+			if decl := tr.typeDecl(tSpec.Name, isGlobal); decl != "" {
+				tr.addLine(tSpec.Pos())
+				tr.WriteString(decl)
+			}
+			continue
 		}
 
 		if tr.hasError {
@@ -351,28 +507,28 @@ func (tr *transform) writeVar(names interface{}, values []ast.Expr, type_ interf
 	// === Names
 	var _names        []string
 	var idxValidNames []int // index of variables which are not in blank
-	var nameIsPointer []bool
+	var nameNode      []ast.Expr
 
 	switch t := names.(type) {
 	case []*ast.Ident:
 		_names = make([]string, len(t))
-		nameIsPointer = make([]bool, len(t))
+		nameNode = make([]ast.Expr, len(t))
 
 		for i, v := range t {
-			expr := tr.getExpression(v)
+			expr := tr.getTarget(v)
 
 			_names[i] = expr.String()
-			nameIsPointer[i] = expr.isPointer
+			nameNode[i] = v
 		}
 	case []ast.Expr: // like avobe
 		_names = make([]string, len(t))
-		nameIsPointer = make([]bool, len(t))
+		nameNode = make([]ast.Expr, len(t))
 
 		for i, v := range t {
-			expr := tr.getExpression(v)
+			expr := tr.getTarget(v)
 
 			_names[i] = expr.String()
-			nameIsPointer[i] = expr.isPointer
+			nameNode[i] = v
 		}
 	default:
 		panic("unreachable")
@@ -393,31 +549,64 @@ func (tr *transform) writeVar(names interface{}, values []ast.Expr, type_ interf
 	}
 
 	if values != nil {
+		// === Type assertion: v, ok := x.(T)
+		if assert, ok := values[0].(*ast.TypeAssertExpr); ok && len(values) == 1 && len(_names) == 2 {
+			x := tr.newExpression(nil)
+			x.writeTypeAssert(assert, true)
+
+			if len(idxValidNames) == 1 {
+				i := idxValidNames[0]
+				tr.WriteString(_names[i] + SP + sign + SP +
+					tr.defValue(nameNode[i], fmt.Sprintf("%s[%d]", x, i)) + ";")
+				return
+			}
+			tr.WriteString(fmt.Sprintf("_%s,%s,%s;", SP+sign+SP+x.String(),
+				SP+_names[0]+SP+sign+SP+tr.defValue(nameNode[0], "_[0]"),
+				SP+_names[1]+SP+sign+SP+tr.defValue(nameNode[1], "_[1]")))
+			return
+		}
+
 		// === Function
-		if call, ok := values[0].(*ast.CallExpr); ok {
+		if call, ok := values[0].(*ast.CallExpr); ok && len(values) == 1 {
 
-			// Function literal
-			if _, ok := call.Fun.(*ast.SelectorExpr); ok {
+			// Function literal, or method; but the results of a function
+			// are assigned to several variables.
+			if _, ok := call.Fun.(*ast.SelectorExpr); ok && len(_names) == 1 {
 				goto _noFunc
 			}
 
 			// Declaration of slice/array
-			fun := call.Fun.(*ast.Ident).Name
-			if fun == "make" || fun == "new" {
+			if ident, ok := call.Fun.(*ast.Ident); ok &&
+				(ident.Name == "make" || ident.Name == "new") {
+				goto _noFunc
+			}
+			// Conversion
+			if tr.info != nil && tr.info.Types[call.Fun].IsType() {
 				goto _noFunc
 			}
 
 			// === Assign variable to the output of a function
-			fun = tr.getExpression(call).String()
+			fun := tr.getExpression(call).String()
 
 			if len(_names) == 1 {
-				tr.WriteString(_names[0] + SP + sign + SP + fun + ";")
+				fun = tr.valueTo(tr.typeOf(nameNode[0]), call, fun, 0)
+				tr.WriteString(_names[0] + SP + sign + SP + tr.defValue(nameNode[0], fun) + ";")
 				return
 			}
+
+			// The result "i" of the array "results"; boxed if it is
+			// assigned to an interface.
+			result := func(results string, i int) string {
+				r := fmt.Sprintf("%s[%d]", results, i)
+				if t, ok := tr.typeOf(call).(*types.Tuple); ok && isBoxed(tr.typeOf(nameNode[i]), t.At(i).Type()) {
+					r = tr.box(t.At(i).Type(), r)
+				}
+				return tr.defValue(nameNode[i], r)
+			}
+
 			if len(idxValidNames) == 1 {
 				i := idxValidNames[0]
-				tr.WriteString(fmt.Sprintf("%s[%d];",
-					_names[i] + SP + sign + SP + fun, i))
+				tr.WriteString(_names[i] + SP + sign + SP + result(fun, i) + ";")
 				return
 			}
 
@@ -425,7 +614,7 @@ func (tr *transform) writeVar(names interface{}, values []ast.Expr, type_ interf
 			str := fmt.Sprintf("_%s", SP+sign+SP+fun)
 
 			for _, i := range idxValidNames {
-				str += fmt.Sprintf(",%s_[%d]", SP+_names[i]+SP+sign+SP, i)
+				str += "," + SP + _names[i] + SP + sign + SP + result("_", i)
 			}
 
 			tr.WriteString(str + ";")
@@ -436,7 +625,6 @@ func (tr *transform) writeVar(names interface{}, values []ast.Expr, type_ interf
 _noFunc:
 	expr := tr.newExpression(nil)
 	typeIs := otherType
-	isFuncLit := false
 	isZeroValue := false
 	isFirst := true
 	value := ""
@@ -449,20 +637,20 @@ _noFunc:
 	for _, i := range idxValidNames {
 		name := _names[i]
 		nameExpr := ""
+		sep := ""
 
 		tr.lastVarName = name
 
 		// === Name
-		if isFirst {
-			nameExpr += name
-			isFirst = false
-		} else {
-			 nameExpr += "," + SP + name
+		if !isFirst {
+			sep = "," + SP
 		}
+		isFirst = false
 
-		if !isNewVar {
-			nameExpr += tagPointer(false, 'P', tr.funcId, tr.blockId, name)
+		if isNewVar {
+			nameExpr += tr.mark(nameNode[i].Pos(), name)
 		}
+		nameExpr += name
 
 		// === Value
 		if isZeroValue {
@@ -479,104 +667,104 @@ _noFunc:
 				valueOfValidName = values[i]
 			}
 
-			// If the expression is an anonymous function, then, at transforming,
-			// it is written in the main buffer.
 			expr = tr.newExpression(name)
-			expr.isValue = true
+			expr.transform(valueOfValidName)
+			exprStr := expr.String()
+
+			// The operation is written like "x = x op y".
+			if op, ok := assignOp[operator]; ok && isLowered(op, tr.typeOf(nameNode[i])) {
+				tr.isVar = false
+				x := tr.getExpression(nameNode[i])
+				tr.isVar = true
+
+				exprStr, _ = tr.binaryOp(op, tr.typeOf(nameNode[i]), x, expr, valueOfValidName)
+				sign = "="
+			} else if isBitClear {
+				exprStr = "~(" + exprStr + ")"
+			} else {
+				exprStr = tr.valueTo(tr.typeOf(nameNode[i]), valueOfValidName, exprStr, expr.prec)
+			}
+			value = exprStr
 
-			if _, ok := valueOfValidName.(*ast.FuncLit); !ok {
-				expr.transform(valueOfValidName)
-				exprStr := expr.String()
+			_, typeIs = tr.zeroValue(false, type_)
 
-				if isBitClear {
-					exprStr = "~(" + exprStr + ")"
-				}
-				value = exprStr
+			// == Map: v, ok := m[k]
+			if len(values) == 1 && tr.isType(mapType, indexedExpr(valueOfValidName), expr.mapName) {
+				get := expr.String()
+				get = get[:len(get)-3] // remove '[0]'
 
-				_, typeIs = tr.zeroValue(false, type_)
-
-				if expr.isAddress {
-					tr.addr[tr.funcId][tr.blockId][name] = true
-					if !isNewVar {
-						nameExpr += ADDR
+				// The value got is copied, like the one of "m[k]".
+				if len(idxValidNames) == 1 {
+					i := idxValidNames[0]
+					if i == 1 {
+						value = get + "[1]"
 					}
-				} /*else {
-					tr.addr[tr.funcId][tr.blockId][name] = false
-				}*/
-
-				// == Map: v, ok := m[k]
-				if len(values) == 1 && tr.isType(mapType, expr.mapName) {
-					value = value[:len(value)-3] // remove '[0]'
-
-					if len(idxValidNames) == 1 {
-						tr.WriteString(fmt.Sprintf("%s%s%s[%d];",
-							_names[idxValidNames[0]],
-							SP + sign + SP,
-							value, idxValidNames[0]))
-					} else {
-						tr.WriteString(fmt.Sprintf("_%s,%s_[%d],%s_[%d];",
-							SP + sign + SP + value,
-							SP + _names[0] + SP + sign + SP, 0,
-							SP + _names[1] + SP + sign + SP, 1))
+					tr.WriteString(fmt.Sprintf("%s%s%s;",
+						_names[i],
+						SP + sign + SP,
+						tr.defValue(nameNode[i], value)))
+				} else {
+					elem := "_[0]"
+					if t := tr.typeOf(indexedExpr(valueOfValidName)); t != nil {
+						elemType := t.Underlying().(*types.Map).Elem()
+						elem = tr.cloneOf(elemType, elem, 0)
+
+						if isBoxed(tr.typeOf(nameNode[0]), elemType) {
+							elem = tr.box(elemType, elem)
+						}
 					}
 
-					return
+					tr.WriteString(fmt.Sprintf("_%s,%s,%s;",
+						SP + sign + SP + get,
+						SP + _names[0] + SP + sign + SP + tr.defValue(nameNode[0], elem),
+						SP + _names[1] + SP + sign + SP + tr.defValue(nameNode[1], "_[1]")))
 				}
-				// ==
-			} else {
-				isFuncLit = true
 
-				tr.WriteString(nameExpr)
-				expr.transform(valueOfValidName)
+				return
 			}
+			// ==
 
 			// Check if new variables assigned to another ones are slices or maps.
 			if isNewVar && expr.isIdent {
-				if tr.isType(sliceType, value) {
+				if tr.isType(sliceType, valueOfValidName, value) {
 					tr.slices[tr.funcId][tr.blockId][name] = void
 				}
-				if tr.isType(mapType, value) {
+				if tr.isType(mapType, valueOfValidName, value) {
 					tr.maps[tr.funcId][tr.blockId][name] = void
 				}
 			}
 		}
 
 		if isNewVar {
-			typeIsPointer := false
-			if typeIs == pointerType {
-				typeIsPointer = true
-			}
-
-			tr.vars[tr.funcId][tr.blockId][name] = typeIsPointer
+			tr.vars[tr.funcId][tr.blockId][name] = void
+		}
 
-			// The value could be a pointer so this new variable has to be it.
-			if tr.vars[tr.funcId][tr.blockId][value] {
-				tr.vars[tr.funcId][tr.blockId][name] = true
-			}
+		// The struct or array pointed is changed in place.
+		if star, ok := nameNode[i].(*ast.StarExpr); ok && sign == "=" {
+			if t := tr.typeOf(star); t != nil && isComposite(t) {
+				tr.isVar = false
+				x := tr.getExpression(star.X)
+				tr.isVar = true
 
-			// Could be addressed ahead
-			if value != "" && !expr.isPointer && !expr.isAddress && !typeIsPointer {
-				value = tagPointer(isZeroValue, 'L', tr.funcId, tr.blockId, name) +
-					value +
-					tagPointer(isZeroValue, 'R', tr.funcId, tr.blockId, name)
+				tr.WriteString(fmt.Sprintf("%sObject.assign(%s,%s)", sep, x, SP+value))
+				continue
 			}
 		}
 
-		if !isFuncLit {
-			tr.WriteString(nameExpr)
-
-			if expr.isSlice {
-				if isNewVar {
-					tr.WriteString(fmt.Sprintf("%sg.NewSlice(%s)", SP+sign+SP, value))
-				} else {
-					tr.WriteString(".set(" + value + ")")
-				}
-			} else if expr.isMake {
-				tr.WriteString(fmt.Sprintf("%sg.MakeSlice(%s)", SP+sign+SP, value))
+		tr.WriteString(sep + nameExpr)
 
-			} else if value != "" {
-				tr.WriteString(SP + sign + SP + value)
+		// The variables addressed are declared like boxes.
+		if expr.isSlice {
+			if isNewVar {
+				tr.WriteString(SP + sign + SP + tr.defValue(nameNode[i], "g.NewSlice("+value+")"))
+			} else {
+				tr.WriteString(".set(" + value + ")")
 			}
+		} else if expr.isMake {
+			tr.WriteString(SP + sign + SP + tr.defValue(nameNode[i], "g.MakeSlice("+value+")"))
+
+		} else if value != "" {
+			tr.WriteString(SP + sign + SP + tr.defValue(nameNode[i], value))
 		}
 	}
 
@@ -632,27 +820,38 @@ func (tr *transform) zeroValue(init bool, typ interface{}) (value string, dt dat
 			tr.skipSemicolon = true
 			return tr.getExpression(t).String(), otherType
 		}
-		if !Bootstrap {
+		if !tr.opts.Bootstrap {
 			return fmt.Sprintf("new g.S([],%s0,%s0)", SP, SP), sliceType
 		}
 		return "[]", sliceType
 
-	case *ast.InterfaceType: // nil
+	case *ast.InterfaceType, *ast.FuncType: // nil
 		return "undefined", otherType
 
 	case *ast.Ident:
 		ident = t
-	case *ast.StarExpr:
-		tr.initIsPointer = true
-		return tr.zeroValue(init, t.X)
+	case *ast.StarExpr: // nil
+		if x := tr.typeOf(t.X); x != nil && isComposite(x) {
+			return "undefined", otherType
+		}
+		return "undefined", pointerType
 	default:
-		panic(fmt.Sprintf("zeroValue(): unexpected type: %T", typ))
+		if node, ok := typ.(ast.Node); ok {
+			tr.addError(node.Pos(), "unsupported-type",
+				"zero value of type of kind %s", nodeKind(node))
+		}
+		return
 	}
 
-	if !init {
-		if tr.initIsPointer {
-			return "", pointerType
+	// The named types, excepting the structs and arrays, are initialized
+	// like their underlying type.
+	if t := tr.typeOf(ident); t != nil {
+		if _, ok := t.(*types.Named); ok && !isComposite(t) {
+			return tr.zeroOf(init, t.Underlying())
 		}
+	}
+
+	if !init {
 		return
 	}
 
@@ -661,37 +860,71 @@ func (tr *transform) zeroValue(init bool, typ interface{}) (value string, dt dat
 		value = "false"
 	case "string":
 		value = EMPTY
-	case "uint", "uint8", "uint16", "uint32", "uint64",
-		"int", "int8", "int16", "int32", "int64",
+	case "int64":
+		value = literal64(types.Typ[types.Int64], 0)
+	case "uint64":
+		value = literal64(types.Typ[types.Uint64], 0)
+	case "uint", "uint8", "uint16", "uint32",
+		"int", "int8", "int16", "int32",
 		"float32", "float64",
 		"byte", "rune", "uintptr":
 		value = "0"
 	case "complex64", "complex128":
-		value = "(0+0i)"
+		value = literalComplex(types.Typ[types.Complex128], 0, 0)
 	default:
-		value = ident.Name
-		value = fmt.Sprintf("new %s(%s)", value, tr.zeroOfType(value))
-	}
-
-	if tr.initIsPointer {
-		value = "{p:undefined}"
-		dt = pointerType
-		tr.initIsPointer = false
+		value = fmt.Sprintf("new %s(%s)", ident.Name, tr.zeroOfType(ident))
 	}
 	return
 }
 
-// Returns the zero value of a map.
-func (tr *transform) zeroOfMap(m *ast.MapType) string {
-	if mapT, ok := m.Value.(*ast.MapType); ok { // nested map
-		return tr.zeroOfMap(mapT)
+// Returns the zero value of the type if "init"; and its kind of data.
+// The structs and the arrays are built like expressions.
+func (tr *transform) zeroOf(init bool, typ types.Type) (value string, dt dataType) {
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case t.Info()&types.IsBoolean != 0:
+			value = "false"
+		case t.Info()&types.IsString != 0:
+			value = EMPTY
+		case isObject(t):
+			value, _ = tr.constLiteral(token.NoPos, t, exact.MakeInt64(0))
+		default:
+			value = "0"
+		}
+	case *types.Map:
+		return "", mapType
+	case *types.Pointer:
+		if isComposite(t.Elem()) {
+			value = "undefined"
+			break
+		}
+		value, dt = "undefined", pointerType
+	case *types.Slice:
+		value, dt = fmt.Sprintf("new g.S([],%s0,%s0)", SP, SP), sliceType
+	case *types.Array:
+		zero, _ := tr.zeroOf(true, t.Elem())
+		value = fmt.Sprintf("g.MakeArray(%d,%sfunction()%s{%sreturn %s;%s})",
+			t.Len(), SP, SP, SP, zero, SP)
+	case *types.Struct:
+		if named, ok := typ.(*types.Named); ok {
+			name := ast.NewIdent(named.Obj().Name())
+			value = fmt.Sprintf("new %s(%s)", name.Name, tr.zeroOfType(name))
+		}
+	default: // interface, function, channel
+		value = "undefined"
+	}
+
+	if !init {
+		value = ""
 	}
-	v, _ := tr.zeroValue(true, m.Value)
-	return v
+	return
 }
 
 // Returns the zero value of a custom type.
-func (tr *transform) zeroOfType(name string) string {
+func (tr *transform) zeroOfType(ident *ast.Ident) string {
+	name := ident.Name
+
 	// In the actual function
 	if tr.funcId != 0 {
 		for block := tr.blockId; block >= 1; block-- {
@@ -708,20 +941,24 @@ func (tr *transform) zeroOfType(name string) string {
 		}
 	}
 	//fmt.Printf("Function %d, block %d, name %s\n", tr.funcId, tr.blockId, name)
-	panic("zeroOfType: type not found: " + name)
+	tr.addError(ident.Pos(), "unsupported-type",
+		"type %q used before of its declaration", name)
+	return ""
 }
 
 // === Checking
 //
 
-// Checks if a variable name is of a specific data type.
-func (tr *transform) isType(t dataType, name string) bool {
+// Checks if the expression is of a specific data type. If its type is
+// unknown, then it is checked the variable named "name".
+func (tr *transform) isType(t dataType, expr ast.Expr, name string) bool {
+	if typ := tr.typeOf(expr); typ != nil {
+		return dataTypeOf(typ) == t
+	}
 	if name == "" {
 		return false
 	}
 
-	name = strings.SplitN(name, "<<", 2)[0] // could have a tag
-
 	for funcId := tr.funcId; funcId >= 0; funcId-- {
 		for blockId := tr.blockId; blockId >= 0; blockId-- {
 			if _, ok := tr.vars[funcId][blockId][name]; ok { // variable found